@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/complete-profile": {
+        "/admin/accounts/{uid}/suspend": {
             "post": {
-                "description": "Permite a los usuarios autenticados completar o actualizar su perfil, incluida la foto de perfil",
+                "description": "Suspende a un usuario o empresa con un motivo y una fecha de término opcional. La cuenta se deshabilita en Firebase y se revocan sus sesiones. Una suspensión activa anterior se reemplaza",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,9 +25,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "admin"
                 ],
-                "summary": "Completar o actualizar perfil de usuario",
+                "summary": "Suspender una cuenta",
                 "parameters": [
                     {
                         "type": "string",
@@ -37,56 +37,66 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Datos para actualizar el perfil",
-                        "name": "profile",
+                        "type": "string",
+                        "description": "UID de Firebase de la cuenta",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo y fecha de término opcional",
+                        "name": "suspension",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.ProfileUpdateRequest"
+                            "$ref": "#/definitions/auth.SuspendAccountRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Perfil actualizado correctamente",
+                        "description": "Suspensión aplicada",
                         "schema": {
-                            "$ref": "#/definitions/auth.SuccessResponse"
+                            "$ref": "#/definitions/models.Suspension_cuenta"
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "Datos inválidos o intento de suspender la propia cuenta",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Usuario no autenticado",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error al actualizar el perfil",
+                    "404": {
+                        "description": "Usuario no encontrado",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Error al actualizar la cuenta en Firebase",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/complete-profile/empresa": {
-            "post": {
-                "description": "Permite a los usuarios autenticados completar o actualizar su perfil de empresa",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/accounts/{uid}/suspensions": {
+            "get": {
+                "description": "Lista las suspensiones de una cuenta, de la más reciente a la más antigua",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "admin"
                 ],
-                "summary": "Completar o actualizar perfil de usuario empresa",
+                "summary": "Historial de suspensiones",
                 "parameters": [
                     {
                         "type": "string",
@@ -96,92 +106,161 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Datos para actualizar el perfil",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "UID de Firebase de la cuenta",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suspensiones de la cuenta",
                         "schema": {
-                            "$ref": "#/definitions/auth.ProfileUpdateRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Suspension_cuenta"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/admin/accounts/{uid}/unsuspend": {
+            "post": {
+                "description": "Levanta la suspensión activa, habilita la cuenta en Firebase y quita la marca en la base de datos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Levantar la suspensión de una cuenta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UID de Firebase de la cuenta",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Perfil actualizado correctamente",
+                        "description": "Suspensión levantada",
                         "schema": {
                             "$ref": "#/definitions/auth.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Datos inválidos",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Usuario no autenticado",
+                    "404": {
+                        "description": "La cuenta no tiene una suspensión activa",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error al actualizar el perfil",
+                    "502": {
+                        "description": "Error al actualizar la cuenta en Firebase",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/login/company": {
-            "post": {
-                "description": "Autentica a una empresa y devuelve un token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/carreras": {
+            "get": {
+                "description": "Lista todas las carreras del catálogo, incluidas las inactivas y las que aún no tienen sede, con los mismos filtros que /carreras",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Inicia sesión una empresa",
+                "summary": "Listar todas las carreras",
                 "parameters": [
                     {
-                        "description": "Datos de inicio de sesión",
-                        "name": "company",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id_universidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id_facultad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id_sede",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Inicio de sesión exitoso",
+                        "description": "Carreras",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Carrera"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "Filtro inválido",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Credenciales incorrectas",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/login/user": {
+            },
             "post": {
-                "description": "Autentica al usuario y devuelve un token",
+                "description": "Agrega una carrera al catálogo. El código se guarda en mayúsculas y debe ser único",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,45 +268,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Inicia sesión un usuario",
+                "summary": "Crear carrera",
                 "parameters": [
                     {
-                        "description": "Datos de inicio de sesión",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la carrera",
+                        "name": "carrera",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginRequest"
+                            "$ref": "#/definitions/academico.CarreraRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Inicio de sesión exitoso",
+                    "201": {
+                        "description": "Carrera creada",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponse"
+                            "$ref": "#/definitions/models.Carrera"
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "Datos inválidos o sede inexistente",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Credenciales incorrectas",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El código de carrera ya existe",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/password-reset": {
-            "post": {
-                "description": "Permite a los usuarios recuperar su contraseña mediante un correo de recuperación",
+        "/admin/carreras/{id}": {
+            "put": {
+                "description": "Actualiza los datos de una carrera. Desactivarla impide elegirla en nuevos registros sin afectar a los usuarios que ya la tienen",
                 "consumes": [
                     "application/json"
                 ],
@@ -235,52 +327,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "password"
+                    "admin"
                 ],
-                "summary": "Envía un correo de recuperación de contraseña",
+                "summary": "Actualizar carrera",
                 "parameters": [
                     {
-                        "description": "Correo del usuario",
-                        "name": "email",
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carrera",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la carrera",
+                        "name": "carrera",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.PasswordResetRequest"
+                            "$ref": "#/definitions/academico.CarreraRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Correo de recuperación enviado con éxito",
+                        "description": "Carrera actualizada",
                         "schema": {
-                            "$ref": "#/definitions/auth.SuccessResponse"
+                            "$ref": "#/definitions/models.Carrera"
                         }
                     },
                     "400": {
-                        "description": "Email requerido",
+                        "description": "Datos inválidos o sede inexistente",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error al enviar el correo de recuperación",
+                    "404": {
+                        "description": "Carrera no encontrada",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El código de carrera ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/profile-status": {
-            "get": {
-                "description": "Retorna si el perfil ha sido completado o no",
+            },
+            "delete": {
+                "description": "Elimina una carrera del catálogo. Si hay usuarios con la carrera se rechaza; en ese caso se debe desactivar",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "admin"
                 ],
-                "summary": "Obtener estado del perfil",
+                "summary": "Eliminar carrera",
                 "parameters": [
                     {
                         "type": "string",
@@ -288,46 +398,50 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carrera",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Estado del perfil",
-                        "schema": {
-                            "$ref": "#/definitions/auth.ProfileStatusResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Datos inválidos",
+                        "description": "Carrera eliminada",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Usuario no autenticado",
+                    "404": {
+                        "description": "Carrera no encontrada",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error interno del servidor",
+                    "409": {
+                        "description": "La carrera tiene usuarios asignados",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/profile-status-empresa": {
+        "/admin/email-outbox": {
             "get": {
-                "description": "Retorna si el perfil ha sido completado o no",
+                "description": "Lista los correos encolados para un destinatario con su estado (pendiente, enviando, enviado, fallido), intentos y último error",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "admin"
                 ],
-                "summary": "Obtener estado del perfil",
+                "summary": "Estado de entrega de correos",
                 "parameters": [
                     {
                         "type": "string",
@@ -335,88 +449,1670 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Correo del destinatario",
+                        "name": "correo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cantidad máxima de resultados (por defecto 20)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Estado del perfil",
+                        "description": "Correos del destinatario",
                         "schema": {
-                            "$ref": "#/definitions/auth.ProfileStatusResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Correo_outbox"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "Correo requerido",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Usuario no autenticado",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/register/user": {
+        "/admin/email-outbox/{id}/retry": {
             "post": {
-                "description": "Crea un nuevo usuario en Firebase y lo guarda en la base de datos local",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Reinicia los intentos de un correo en estado fallido para que el worker lo vuelva a enviar",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Registra un nuevo usuario",
+                "summary": "Reintentar un correo fallido",
                 "parameters": [
                     {
-                        "description": "Datos del usuario a registrar",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del correo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Usuario registrado correctamente",
+                        "description": "Correo reencolado",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Solicitud inválida",
+                    "403": {
+                        "description": "Acceso restringido a administradores",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error interno del servidor",
+                    "404": {
+                        "description": "Correo no encontrado o no está fallido",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/register_empresa": {
-            "post": {
-                "description": "Crea un nuevo usuario en Firebase y lo guarda en la base de datos local",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/email-templates/{name}/preview": {
+            "get": {
+                "description": "Renderiza una plantilla de correo con datos de ejemplo y la marca de la aplicación indicada",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Previsualizar plantilla de correo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre de la plantilla (verification, password_reset, welcome, company_approval, security_alert)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aplicación (ulink, practicas, descuentos, roomies)",
+                        "name": "app",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idioma (es, en); por defecto el de Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Formato (html o text)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plantilla renderizada",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Plantilla no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/facultades": {
+            "get": {
+                "description": "Lista todas las facultades, incluidas las inactivas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar facultades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id_universidad",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Facultades",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Facultad"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Crear facultad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la facultad",
+                        "name": "facultad",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.FacultadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Facultad creada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Facultad"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o universidad inexistente",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/facultades/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Actualizar facultad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la facultad",
+                        "name": "facultad",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.FacultadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Facultad actualizada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Facultad"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o universidad inexistente",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Facultad no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina una facultad. Si tiene sedes se rechaza; en ese caso se debe desactivar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Eliminar facultad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Facultad eliminada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Facultad no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tiene elementos dependientes",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sedes": {
+            "get": {
+                "description": "Lista todas las sedes, incluidas las inactivas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar sedes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id_facultad",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sedes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Sede"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Crear sede",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la sede",
+                        "name": "sede",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.SedeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sede creada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Sede"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o facultad inexistente",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sedes/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Actualizar sede",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la sede",
+                        "name": "sede",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.SedeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sede actualizada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Sede"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o facultad inexistente",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sede no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina una sede. Si tiene carreras se rechaza; en ese caso se debe desactivar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Eliminar sede",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sede eliminada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Sede no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tiene elementos dependientes",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/universidades": {
+            "get": {
+                "description": "Lista todas las universidades, incluidas las inactivas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar universidades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Universidades",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Universidad"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Crear universidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la universidad",
+                        "name": "universidad",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.UniversidadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Universidad creada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Universidad"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El código de universidad ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/universidades/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Actualizar universidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la universidad",
+                        "name": "universidad",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/academico.UniversidadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Universidad actualizada",
+                        "schema": {
+                            "$ref": "#/definitions/models.Universidad"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Universidad no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El código de universidad ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina una universidad. Si tiene facultades se rechaza; en ese caso se debe desactivar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Eliminar universidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Universidad eliminada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Universidad no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tiene elementos dependientes",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/usuarios": {
+            "get": {
+                "description": "Lista los usuarios con su carrera, filtrados por universidad, facultad, sede o carrera",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Listar usuarios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id_universidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id_facultad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id_sede",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carrera",
+                        "name": "id_carrera",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (por defecto 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Usuarios por página (por defecto 50, máximo 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuarios",
+                        "schema": {
+                            "$ref": "#/definitions/admin.UsuariosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro inválido",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/usuarios/stats": {
+            "get": {
+                "description": "Cuenta los usuarios agrupados por universidad, facultad, sede o carrera, con los mismos filtros que el listado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Estadísticas de usuarios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nivel de agrupación (universidad, facultad, sede, carrera)",
+                        "name": "group_by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id_universidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id_facultad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id_sede",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carrera",
+                        "name": "id_carrera",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuarios por nivel",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/admin.UsuariosStat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro o nivel inválido",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Acceso restringido a administradores",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/carreras": {
+            "get": {
+                "description": "Lista las carreras activas ordenadas por nombre, opcionalmente filtradas por cualquier nivel de la jerarquía. Solo incluye carreras cuya sede, facultad y universidad están activas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carreras"
+                ],
+                "summary": "Listar carreras",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la universidad",
+                        "name": "id_universidad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la facultad",
+                        "name": "id_facultad",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la sede",
+                        "name": "id_sede",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Carreras activas",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Carrera"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro inválido",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/change-password": {
+            "post": {
+                "description": "Verifica la contraseña actual y la reemplaza por una nueva que cumpla la política de seguridad",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "password"
+                ],
+                "summary": "Cambiar contraseña",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Contraseña actual y nueva",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Contraseña actualizada correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "La contraseña no cumple la política de seguridad",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Contraseña actual incorrecta",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error al actualizar la contraseña",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/complete-profile": {
+            "post": {
+                "description": "Permite a los usuarios autenticados completar o actualizar su perfil, incluida la foto de perfil",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Completar o actualizar perfil de usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos para actualizar el perfil",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ProfileUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Perfil actualizado correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos con detalle por campo (fecha fuera del rango de edad, año de ingreso futuro o anterior a la carrera) o carrera inexistente o inactiva (carrera_invalid)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error al actualizar el perfil",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/complete-profile/empresa": {
+            "post": {
+                "description": "Permite a los usuarios autenticados completar o actualizar su perfil de empresa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Completar o actualizar perfil de usuario empresa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos para actualizar el perfil",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ProfileUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Perfil actualizado correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error al actualizar el perfil",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/error-codes": {
+            "get": {
+                "description": "Lista todos los códigos de error estables que puede devolver la API con su estado HTTP y mensaje en el idioma de Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "errors"
+                ],
+                "summary": "Catálogo de códigos de error",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Idioma de los mensajes (es, en)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Códigos de error",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apierror.CatalogEntry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Responde mientras el proceso esté vivo. Pensado para el liveness probe de Kubernetes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Estado del proceso",
+                "responses": {
+                    "200": {
+                        "description": "Proceso vivo",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/language": {
+            "put": {
+                "description": "Guarda el idioma usado en las respuestas de la API y en los correos. Si no hay preferencia se usa Accept-Language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Cambiar idioma preferido",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Idioma (es, en)",
+                        "name": "idioma",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.UpdateLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Idioma actualizado correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Idioma no soportado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/company": {
+            "post": {
+                "description": "Autentica a una empresa y devuelve un token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Inicia sesión una empresa",
+                "parameters": [
+                    {
+                        "description": "Datos de inicio de sesión",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inicio de sesión exitoso",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos (invalid_request, invalid_email, missing_password)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciales incorrectas (invalid_credentials, user_not_found, company_not_found)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Cuenta deshabilitada (user_disabled), suspendida (account_suspended) o correo sin verificar (email_not_verified)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiados intentos (too_many_attempts)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Servicio de autenticación no disponible (signin_unavailable)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/user": {
+            "post": {
+                "description": "Autentica al usuario y devuelve un token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Inicia sesión un usuario",
+                "parameters": [
+                    {
+                        "description": "Datos de inicio de sesión",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inicio de sesión exitoso",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos (invalid_request, invalid_email, missing_password)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciales incorrectas (invalid_credentials, user_not_found, company_not_found)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Cuenta deshabilitada (user_disabled), suspendida (account_suspended) o correo sin verificar (email_not_verified)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiados intentos (too_many_attempts)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Servicio de autenticación no disponible (signin_unavailable)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password-reset": {
+            "post": {
+                "description": "Envía un enlace de un solo uso para restablecer la contraseña. La respuesta es la misma exista o no la cuenta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "password"
+                ],
+                "summary": "Envía un correo de recuperación de contraseña",
+                "parameters": [
+                    {
+                        "description": "Correo del usuario",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Si el correo está registrado, se enviará un enlace de recuperación",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Email requerido",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error al enviar el correo de recuperación",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password-reset/confirm": {
+            "post": {
+                "description": "Valida el token de un solo uso, establece la nueva contraseña, cierra las sesiones abiertas y envía un correo de confirmación",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "password"
+                ],
+                "summary": "Confirmar restablecimiento de contraseña",
+                "parameters": [
+                    {
+                        "description": "Token y nueva contraseña",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.PasswordResetConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Contraseña restablecida correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Token inválido o contraseña que no cumple la política",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error al restablecer la contraseña",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile-status": {
+            "get": {
+                "description": "Retorna si el perfil ha sido completado o no",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Obtener estado del perfil",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estado del perfil",
+                        "schema": {
+                            "$ref": "#/definitions/auth.ProfileStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile-status-empresa": {
+            "get": {
+                "description": "Retorna si el perfil ha sido completado o no",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Obtener estado del perfil",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estado del perfil",
+                        "schema": {
+                            "$ref": "#/definitions/auth.ProfileStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Usuario no autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Verifica la conexión con PostgreSQL, las credenciales de Firebase, el bucket de Storage y el servidor SMTP. Pensado para el readiness probe de Kubernetes: solo una falla de PostgreSQL responde 503; las demás dependencias se informan como degraded. El detalle de las fallas queda en los logs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Estado de las dependencias",
+                "responses": {
+                    "200": {
+                        "description": "Dependencias obligatorias disponibles (status ok o degraded)",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "PostgreSQL no está disponible",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/register/user": {
+            "post": {
+                "description": "Crea un nuevo usuario en Firebase y lo guarda en la base de datos local",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Registra un nuevo usuario",
+                "parameters": [
+                    {
+                        "description": "Datos del usuario a registrar",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuario registrado correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Solicitud inválida, carrera inexistente o inactiva, o contraseña que no cumple la política, o correo con una cuenta existente y otra contraseña",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register_empresa": {
+            "post": {
+                "description": "Crea un nuevo usuario en Firebase y lo guarda en la base de datos local",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -443,15 +2139,15 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Solicitud inválida",
+                        "description": "Solicitud inválida o contraseña que no cumple la política, o correo con una cuenta existente y otra contraseña",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse_empresa"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse_empresa"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
@@ -491,19 +2187,48 @@ const docTemplate = `{
                     "400": {
                         "description": "Email requerido",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Usuario no encontrado",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/universidades": {
+            "get": {
+                "description": "Devuelve las universidades activas con sus facultades, sedes y carreras activas, para llenar los selectores en cascada del registro",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carreras"
+                ],
+                "summary": "Jerarquía académica",
+                "responses": {
+                    "200": {
+                        "description": "Jerarquía académica",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/academico.UniversidadNodo"
+                            }
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "$ref": "#/definitions/auth.ErrorResponse"
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     }
                 }
@@ -551,50 +2276,373 @@ const docTemplate = `{
                     "400": {
                         "description": "Error en la solicitud",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Usuario no autenticado",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Archivo demasiado grande (máximo 10 MiB)",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error al subir la imagen",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email/code": {
+            "post": {
+                "description": "Verifica el correo del usuario usando el código de 6 dígitos enviado por email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "verification"
+                ],
+                "summary": "Verificar correo con código",
+                "parameters": [
+                    {
+                        "description": "Correo y código de verificación",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.VerifyCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Correo verificado exitosamente",
+                        "schema": {
+                            "$ref": "#/definitions/auth.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Código inválido o expirado",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiados intentos",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Devuelve la versión, el commit y la fecha de compilación del binario en ejecución",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Versión de la API",
+                "responses": {
+                    "200": {
+                        "description": "Información de la compilación",
+                        "schema": {
+                            "$ref": "#/definitions/buildinfo.Info"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.EmailRequest": {
+        }
+    },
+    "definitions": {
+        "academico.CarreraRequest": {
+            "type": "object",
+            "required": [
+                "Codigo",
+                "Id_sede",
+                "Nombre"
+            ],
+            "properties": {
+                "Activa": {
+                    "description": "Activa es opcional; por defecto la carrera queda activa",
+                    "type": "boolean"
+                },
+                "Ano_apertura": {
+                    "description": "Ano_apertura es opcional; el año de ingreso de los usuarios no puede ser anterior",
+                    "type": "integer",
+                    "minimum": 1900,
+                    "example": 1995
+                },
+                "Codigo": {
+                    "type": "string",
+                    "example": "ICI"
+                },
+                "Duracion_semestres": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1,
+                    "example": 12
+                },
+                "Id_sede": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "Nombre": {
+                    "type": "string",
+                    "example": "Ingeniería Civil Informática"
+                }
+            }
+        },
+        "academico.FacultadNodo": {
+            "type": "object",
+            "properties": {
+                "Id_facultad": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                },
+                "Sedes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/academico.SedeNodo"
+                    }
+                }
+            }
+        },
+        "academico.FacultadRequest": {
+            "type": "object",
+            "required": [
+                "Id_universidad",
+                "Nombre"
+            ],
+            "properties": {
+                "Activa": {
+                    "description": "Activa es opcional; por defecto queda activa",
+                    "type": "boolean"
+                },
+                "Id_universidad": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "Nombre": {
+                    "type": "string",
+                    "example": "Facultad de Ingeniería"
+                }
+            }
+        },
+        "academico.SedeNodo": {
+            "type": "object",
+            "properties": {
+                "Carreras": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Carrera"
+                    }
+                },
+                "Ciudad": {
+                    "type": "string"
+                },
+                "Id_sede": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "academico.SedeRequest": {
+            "type": "object",
+            "required": [
+                "Id_facultad",
+                "Nombre"
+            ],
+            "properties": {
+                "Activa": {
+                    "description": "Activa es opcional; por defecto queda activa",
+                    "type": "boolean"
+                },
+                "Ciudad": {
+                    "type": "string",
+                    "example": "Curicó"
+                },
+                "Id_facultad": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "Nombre": {
+                    "type": "string",
+                    "example": "Campus Curicó"
+                }
+            }
+        },
+        "academico.UniversidadNodo": {
+            "type": "object",
+            "properties": {
+                "Codigo": {
+                    "type": "string"
+                },
+                "Facultades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/academico.FacultadNodo"
+                    }
+                },
+                "Id_universidad": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "academico.UniversidadRequest": {
+            "type": "object",
+            "required": [
+                "Codigo",
+                "Nombre"
+            ],
+            "properties": {
+                "Activa": {
+                    "description": "Activa es opcional; por defecto queda activa",
+                    "type": "boolean"
+                },
+                "Codigo": {
+                    "type": "string",
+                    "example": "UTAL"
+                },
+                "Nombre": {
+                    "type": "string",
+                    "example": "Universidad de Talca"
+                }
+            }
+        },
+        "admin.UsuariosResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "usuarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Usuario"
+                    }
+                }
+            }
+        },
+        "admin.UsuariosStat": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apierror.CatalogEntry": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apierror.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_request"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Datos inválidos"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apierror.FieldError"
+                    }
+                }
+            }
+        },
+        "apierror.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.ChangePasswordRequest": {
             "type": "object",
             "required": [
-                "email"
+                "current_password",
+                "new_password"
             ],
             "properties": {
-                "email": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
                     "type": "string"
                 }
             }
         },
-        "auth.ErrorResponse": {
+        "auth.EmailRequest": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "error": {
+                "email": {
                     "type": "string"
                 }
             }
@@ -617,11 +2665,33 @@ const docTemplate = `{
         "auth.LoginResponse": {
             "type": "object",
             "properties": {
+                "email_verified": {
+                    "type": "boolean"
+                },
                 "token": {
                     "type": "string"
                 },
                 "uid": {
                     "type": "string"
+                },
+                "verification_deadline": {
+                    "description": "VerificationDeadline indica hasta cuándo se permite iniciar sesión sin verificar el correo",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.PasswordResetConfirmRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
                 }
             }
         },
@@ -648,10 +2718,13 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "ano_ingreso": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2020"
                 },
                 "fecha_nacimiento": {
-                    "type": "string"
+                    "description": "AAAA-MM-DD",
+                    "type": "string",
+                    "example": "2001-03-15"
                 },
                 "id_carrera": {
                     "type": "integer"
@@ -732,6 +2805,316 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "auth.SuspendAccountRequest": {
+            "type": "object",
+            "required": [
+                "motivo"
+            ],
+            "properties": {
+                "expira_en": {
+                    "description": "Expira_en es opcional; sin fecha la suspensión es indefinida",
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
+                "motivo": {
+                    "type": "string",
+                    "example": "Publicaciones fraudulentas"
+                }
+            }
+        },
+        "auth.UpdateLanguageRequest": {
+            "type": "object",
+            "required": [
+                "idioma"
+            ],
+            "properties": {
+                "idioma": {
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "auth.VerifyCodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "email"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string",
+                    "example": "2026-10-19T12:00:00Z"
+                },
+                "commit": {
+                    "type": "string",
+                    "example": "5b0a391"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.23.1"
+                },
+                "modified": {
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.4.0"
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "models.Carrera": {
+            "type": "object",
+            "properties": {
+                "Activa": {
+                    "type": "boolean"
+                },
+                "Ano_apertura": {
+                    "description": "Año en que la carrera recibió su primera cohorte",
+                    "type": "integer"
+                },
+                "Codigo": {
+                    "type": "string"
+                },
+                "Duracion_semestres": {
+                    "type": "integer"
+                },
+                "Id_carrera": {
+                    "type": "integer"
+                },
+                "Id_sede": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Correo_outbox": {
+            "type": "object",
+            "properties": {
+                "asunto": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "destinatario": {
+                    "type": "string"
+                },
+                "enviado_en": {
+                    "type": "string"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intentos": {
+                    "type": "integer"
+                },
+                "plantilla": {
+                    "type": "string"
+                },
+                "proximo_intento": {
+                    "type": "string"
+                },
+                "remitente": {
+                    "type": "string"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "ultimo_error": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Facultad": {
+            "type": "object",
+            "properties": {
+                "Activa": {
+                    "type": "boolean"
+                },
+                "Id_facultad": {
+                    "type": "integer"
+                },
+                "Id_universidad": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Sede": {
+            "type": "object",
+            "properties": {
+                "Activa": {
+                    "type": "boolean"
+                },
+                "Ciudad": {
+                    "type": "string"
+                },
+                "Id_facultad": {
+                    "type": "integer"
+                },
+                "Id_sede": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Suspension_cuenta": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expira_en": {
+                    "type": "string"
+                },
+                "firebase_uid": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "levantada_en": {
+                    "type": "string"
+                },
+                "levantada_por": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "type": "string"
+                },
+                "suspendida_por": {
+                    "type": "string"
+                },
+                "tipo_cuenta": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Universidad": {
+            "type": "object",
+            "properties": {
+                "Activa": {
+                    "type": "boolean"
+                },
+                "Codigo": {
+                    "type": "string"
+                },
+                "Id_universidad": {
+                    "type": "integer"
+                },
+                "Nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Usuario": {
+            "type": "object",
+            "properties": {
+                "Ano_Ingreso": {
+                    "type": "integer"
+                },
+                "Apellidos": {
+                    "type": "string"
+                },
+                "Carrera": {
+                    "$ref": "#/definitions/models.Carrera"
+                },
+                "Correo": {
+                    "type": "string"
+                },
+                "Fecha_Nacimiento": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2001-03-15"
+                },
+                "Foto_Perfil": {
+                    "type": "string"
+                },
+                "Id_Estado_Usuario": {
+                    "type": "boolean"
+                },
+                "Id_carrera": {
+                    "type": "integer"
+                },
+                "Idioma": {
+                    "description": "Preferencia de idioma; vacío usa Accept-Language",
+                    "type": "string"
+                },
+                "Nombres": {
+                    "type": "string"
+                },
+                "PerfilCompletado": {
+                    "type": "boolean"
+                },
+                "Rol": {
+                    "type": "string"
+                },
+                "Suspendido": {
+                    "description": "Cuenta suspendida por un administrador",
+                    "type": "boolean"
+                },
+                "firebase_usuario": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
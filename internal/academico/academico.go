@@ -0,0 +1,152 @@
+package academico
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"login/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Niveles de la jerarquía académica por los que se puede filtrar y agrupar
+const (
+	NivelUniversidad = "universidad"
+	NivelFacultad    = "facultad"
+	NivelSede        = "sede"
+	NivelCarrera     = "carrera"
+)
+
+// nivelColumnas indica la tabla de cada nivel para agrupar
+var nivelColumnas = map[string]string{
+	NivelUniversidad: `"Universidad"`,
+	NivelFacultad:    `"Facultad"`,
+	NivelSede:        `"Sede"`,
+	NivelCarrera:     `"Carrera"`,
+}
+
+// Handlers agrupa los endpoints del catálogo académico sobre la base de datos
+type Handlers struct {
+	db *gorm.DB
+}
+
+// NewHandlers crea los handlers del catálogo académico
+func NewHandlers(db *gorm.DB) *Handlers {
+	return &Handlers{db: db}
+}
+
+// Filtro restringe una consulta a un nodo de la jerarquía académica; los campos en cero no filtran
+type Filtro struct {
+	Id_universidad uint
+	Id_facultad    uint
+	Id_sede        uint
+	Id_carrera     uint
+}
+
+// FiltroFromQuery lee id_universidad, id_facultad, id_sede e id_carrera de la query
+func FiltroFromQuery(c *gin.Context) (Filtro, error) {
+	var f Filtro
+	var fields []apierror.FieldError
+	for param, dest := range map[string]*uint{
+		"id_universidad": &f.Id_universidad,
+		"id_facultad":    &f.Id_facultad,
+		"id_sede":        &f.Id_sede,
+		"id_carrera":     &f.Id_carrera,
+	} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			fields = append(fields, apierror.Field(param, "invalid", "field.invalid"))
+			continue
+		}
+		*dest = uint(id)
+	}
+	if len(fields) > 0 {
+		return f, apierror.InvalidRequest.WithFields(fields...)
+	}
+	return f, nil
+}
+
+// JoinUsuarios une "Usuario" con su carrera, sede, facultad y universidad y aplica el filtro.
+// Los usuarios sin carrera se conservan con los niveles en NULL
+func (f Filtro) JoinUsuarios(db *gorm.DB) *gorm.DB {
+	db = db.Joins(`LEFT JOIN "Carrera" ON "Carrera".id_carrera = "Usuario".id_carrera`)
+	return f.joinCarreras(db)
+}
+
+// joinCarreras une "Carrera" con su sede, facultad y universidad y aplica el filtro
+func (f Filtro) joinCarreras(db *gorm.DB) *gorm.DB {
+	db = db.Joins(`LEFT JOIN "Sede" ON "Sede".id_sede = "Carrera".id_sede`).
+		Joins(`LEFT JOIN "Facultad" ON "Facultad".id_facultad = "Sede".id_facultad`).
+		Joins(`LEFT JOIN "Universidad" ON "Universidad".id_universidad = "Facultad".id_universidad`)
+
+	if f.Id_universidad != 0 {
+		db = db.Where(`"Universidad".id_universidad = ?`, f.Id_universidad)
+	}
+	if f.Id_facultad != 0 {
+		db = db.Where(`"Facultad".id_facultad = ?`, f.Id_facultad)
+	}
+	if f.Id_sede != 0 {
+		db = db.Where(`"Sede".id_sede = ?`, f.Id_sede)
+	}
+	if f.Id_carrera != 0 {
+		db = db.Where(`"Carrera".id_carrera = ?`, f.Id_carrera)
+	}
+	return db
+}
+
+// GroupColumns devuelve las columnas de ID y nombre de un nivel para agrupar consultas
+// hechas con JoinUsuarios
+func GroupColumns(nivel string) (id, nombre string, ok bool) {
+	tabla, ok := nivelColumnas[nivel]
+	if !ok {
+		return "", "", false
+	}
+	return tabla + ".id_" + nivel, tabla + ".nombre", true
+}
+
+// carreraSeleccionable limita a carreras activas cuya sede, facultad y universidad también lo están.
+// Requiere los joins de joinCarreras
+func carreraSeleccionable(db *gorm.DB) *gorm.DB {
+	return db.Where(`"Carrera".activa AND "Sede".activa AND "Facultad".activa AND "Universidad".activa`)
+}
+
+// findByID carga el registro con el ID del parámetro de la ruta o devuelve notFound
+func (h *Handlers) findByID(dest interface{}, param string, notFound *apierror.Error) error {
+	id, err := strconv.ParseUint(param, 10, 32)
+	if err != nil {
+		return notFound
+	}
+	if err := h.db.First(dest, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return notFound
+		}
+		return apierror.DatabaseError.Wrap(err)
+	}
+	return nil
+}
+
+// checkExists verifica que exista el registro padre indicado en el campo de la solicitud;
+// el nombre del campo coincide con la columna de su llave primaria
+func (h *Handlers) checkExists(model interface{}, id uint, field string) error {
+	var count int64
+	if err := h.db.Model(model).Where(strings.ToLower(field)+" = ?", id).Count(&count).Error; err != nil {
+		return apierror.DatabaseError.Wrap(err)
+	}
+	if count == 0 {
+		return apierror.InvalidRequest.WithFields(apierror.Field(field, "not_found", "field.not_found"))
+	}
+	return nil
+}
+
+// countDependientes cuenta las filas de model que referencian el ID en la columna indicada
+func (h *Handlers) countDependientes(model interface{}, column string, id uint) (int64, error) {
+	var count int64
+	err := h.db.Model(model).Where(column+" = ?", id).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,119 @@
+package academico
+
+import (
+	"fmt"
+	"log/slog"
+
+	"login/internal/apierror"
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ValidateCarrera verifica que la carrera exista y que esté activa junto con su sede, facultad
+// y universidad. field es el nombre del campo
+// de la solicitud usado en el detalle del error
+func ValidateCarrera(db *gorm.DB, id uint, field string) error {
+	var carrera models.Carrera
+	result := db.Limit(1).Find(&carrera, id)
+	if result.Error != nil {
+		return apierror.DatabaseError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apierror.CarreraInvalid.WithFields(apierror.Field(field, "not_found", "field.carrera_not_found"))
+	}
+	// La carrera debe estar activa junto con su sede, facultad y universidad
+	var seleccionables int64
+	err := Filtro{Id_carrera: id}.joinCarreras(db.Model(&models.Carrera{})).
+		Scopes(carreraSeleccionable).Count(&seleccionables).Error
+	if err != nil {
+		return apierror.DatabaseError.Wrap(err)
+	}
+	if seleccionables == 0 {
+		return apierror.CarreraInvalid.WithFields(apierror.Field(field, "inactive", "field.carrera_inactive"))
+	}
+	return nil
+}
+
+// MigrateCarreraReferences prepara los datos existentes para la llave foránea de Usuario.Id_carrera.
+// Los usuarios sin perfil completo tenían la carrera fija en 1 al registrarse, por lo que quedan sin
+// carrera. Para los demás, cada Id_carrera sin fila en el catálogo se conserva como una carrera
+// inactiva que un administrador debe completar. Corre como la migración 9, por lo que solo puede
+// usar las columnas que existen en esa versión del esquema
+func MigrateCarreraReferences(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		huerfanas := "id_carrera IS NOT NULL AND id_carrera NOT IN (SELECT id_carrera FROM \"Carrera\")"
+
+		result := tx.Model(&models.Usuario{}).
+			Where(huerfanas).
+			Where("perfil_completado = ? OR id_carrera = 0", false).
+			Update("id_carrera", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			slog.Info("Carreras: usuarios sin perfil completo quedaron sin carrera", "usuarios", result.RowsAffected)
+		}
+
+		var ids []uint
+		if err := tx.Model(&models.Usuario{}).Where(huerfanas).Distinct().Pluck("id_carrera", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			// SQL explícito porque models.Carrera ya tiene columnas que se agregan después de esta
+			// versión (id_sede en 0011, ano_apertura en 0014)
+			err := tx.Exec(`INSERT INTO "Carrera" (id_carrera, codigo, nombre, activa) VALUES (?, ?, ?, ?)`,
+				id, fmt.Sprintf("LEGACY-%d", id), fmt.Sprintf("Carrera %d (por revisar)", id), false).Error
+			if err != nil {
+				return err
+			}
+			slog.Info("Carreras: se creó una carrera inactiva para conservar las referencias existentes", "id_carrera", id)
+		}
+
+		if len(ids) > 0 {
+			// Ajustar la secuencia para que las próximas carreras no choquen con los IDs conservados
+			return tx.Exec(`SELECT setval(pg_get_serial_sequence('"Carrera"', 'id_carrera'), (SELECT MAX(id_carrera) FROM "Carrera"))`).Error
+		}
+		return nil
+	})
+}
+
+// MigrateFacultades traslada la antigua columna de texto Carrera.facultad a la jerarquía.
+// Cada facultad distinta se crea con una sede principal bajo una universidad por definir,
+// y sus carreras se asignan a esa sede; luego se elimina la columna
+func MigrateFacultades(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Carrera{}, "facultad") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var facultades []string
+		if err := tx.Raw(`SELECT DISTINCT facultad FROM "Carrera" WHERE id_sede IS NULL AND facultad <> ''`).Scan(&facultades).Error; err != nil {
+			return err
+		}
+
+		if len(facultades) > 0 {
+			universidad := models.Universidad{Codigo: "POR-DEFINIR", Nombre: "Universidad por definir", Activa: true}
+			if err := tx.Where(models.Universidad{Codigo: universidad.Codigo}).FirstOrCreate(&universidad).Error; err != nil {
+				return err
+			}
+
+			for _, nombre := range facultades {
+				facultad := models.Facultad{Id_universidad: universidad.Id_universidad, Nombre: nombre, Activa: true}
+				if err := tx.Create(&facultad).Error; err != nil {
+					return err
+				}
+				sede := models.Sede{Id_facultad: facultad.Id_facultad, Nombre: "Sede principal", Activa: true}
+				if err := tx.Create(&sede).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec(`UPDATE "Carrera" SET id_sede = ? WHERE id_sede IS NULL AND facultad = ?`, sede.Id_sede, nombre).Error; err != nil {
+					return err
+				}
+			}
+			slog.Warn("Carreras: facultades migradas a otra universidad; revisa sus sedes", "facultades", len(facultades), "universidad", universidad.Nombre)
+		}
+
+		return tx.Migrator().DropColumn(&models.Carrera{}, "facultad")
+	})
+}
@@ -0,0 +1,232 @@
+package academico
+
+import (
+	"net/http"
+	"strings"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CarreraRequest estructura para crear o actualizar una carrera
+type CarreraRequest struct {
+	Id_sede            uint   `json:"Id_sede" binding:"required" example:"1"`
+	Codigo             string `json:"Codigo" binding:"required" example:"ICI"`
+	Nombre             string `json:"Nombre" binding:"required" example:"Ingeniería Civil Informática"`
+	Duracion_semestres uint   `json:"Duracion_semestres" binding:"omitempty,min=1,max=20" example:"12"`
+	// Ano_apertura es opcional; el año de ingreso de los usuarios no puede ser anterior
+	Ano_apertura *int16 `json:"Ano_apertura" binding:"omitempty,min=1900" example:"1995"`
+	// Activa es opcional; por defecto la carrera queda activa
+	Activa *bool `json:"Activa"`
+}
+
+func (r CarreraRequest) apply(carrera *models.Carrera) {
+	carrera.Id_sede = &r.Id_sede
+	carrera.Codigo = strings.ToUpper(strings.TrimSpace(r.Codigo))
+	carrera.Nombre = strings.TrimSpace(r.Nombre)
+	carrera.Duracion_semestres = r.Duracion_semestres
+	carrera.Ano_apertura = r.Ano_apertura
+	carrera.Activa = r.Activa == nil || *r.Activa
+}
+
+// ListCarrerasHandler lista las carreras activas para los formularios de registro
+// @Summary Listar carreras
+// @Description Lista las carreras activas ordenadas por nombre, opcionalmente filtradas por cualquier nivel de la jerarquía. Solo incluye carreras cuya sede, facultad y universidad están activas
+// @Tags carreras
+// @Produce json
+// @Param id_universidad query int false "ID de la universidad"
+// @Param id_facultad query int false "ID de la facultad"
+// @Param id_sede query int false "ID de la sede"
+// @Success 200 {array} models.Carrera "Carreras activas"
+// @Failure 400 {object} apierror.ErrorResponse "Filtro inválido"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /carreras [get]
+func (h *Handlers) ListCarrerasHandler(c *gin.Context) {
+	filtro, err := FiltroFromQuery(c)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var carreras []models.Carrera
+	query := filtro.joinCarreras(h.db.WithContext(c.Request.Context()).Model(&models.Carrera{}).Select(`"Carrera".*`)).Scopes(carreraSeleccionable)
+	if err := query.Order(`"Carrera".nombre`).Find(&carreras).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, carreras)
+}
+
+// AdminListCarrerasHandler lista todas las carreras, incluidas las inactivas
+// @Summary Listar todas las carreras
+// @Description Lista todas las carreras del catálogo, incluidas las inactivas y las que aún no tienen sede, con los mismos filtros que /carreras
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id_universidad query int false "ID de la universidad"
+// @Param id_facultad query int false "ID de la facultad"
+// @Param id_sede query int false "ID de la sede"
+// @Success 200 {array} models.Carrera "Carreras"
+// @Failure 400 {object} apierror.ErrorResponse "Filtro inválido"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /admin/carreras [get]
+func (h *Handlers) AdminListCarrerasHandler(c *gin.Context) {
+	filtro, err := FiltroFromQuery(c)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var carreras []models.Carrera
+	query := filtro.joinCarreras(h.db.WithContext(c.Request.Context()).Model(&models.Carrera{}).Select(`"Carrera".*`))
+	if err := query.Order(`"Carrera".nombre`).Find(&carreras).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, carreras)
+}
+
+// CreateCarreraHandler agrega una carrera al catálogo
+// @Summary Crear carrera
+// @Description Agrega una carrera al catálogo. El código se guarda en mayúsculas y debe ser único
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param carrera body CarreraRequest true "Datos de la carrera"
+// @Success 201 {object} models.Carrera "Carrera creada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o sede inexistente"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 409 {object} apierror.ErrorResponse "El código de carrera ya existe"
+// @Router /admin/carreras [post]
+func (h *Handlers) CreateCarreraHandler(c *gin.Context) {
+	var req CarreraRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	if err := h.checkExists(&models.Sede{}, req.Id_sede, "Id_sede"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var carrera models.Carrera
+	req.apply(&carrera)
+	if err := h.checkCodigoDisponible(carrera.Codigo, 0); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	if err := h.db.WithContext(c.Request.Context()).Create(&carrera).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusCreated, carrera)
+}
+
+// UpdateCarreraHandler actualiza una carrera del catálogo
+// @Summary Actualizar carrera
+// @Description Actualiza los datos de una carrera. Desactivarla impide elegirla en nuevos registros sin afectar a los usuarios que ya la tienen
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la carrera"
+// @Param carrera body CarreraRequest true "Datos de la carrera"
+// @Success 200 {object} models.Carrera "Carrera actualizada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o sede inexistente"
+// @Failure 404 {object} apierror.ErrorResponse "Carrera no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "El código de carrera ya existe"
+// @Router /admin/carreras/{id} [put]
+func (h *Handlers) UpdateCarreraHandler(c *gin.Context) {
+	carrera, err := h.findCarrera(c.Param("id"))
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var req CarreraRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	if err := h.checkExists(&models.Sede{}, req.Id_sede, "Id_sede"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	req.apply(carrera)
+	if err := h.checkCodigoDisponible(carrera.Codigo, carrera.Id_carrera); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	if err := h.db.WithContext(c.Request.Context()).Save(carrera).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, carrera)
+}
+
+// DeleteCarreraHandler elimina una carrera que ningún usuario tiene asignada
+// @Summary Eliminar carrera
+// @Description Elimina una carrera del catálogo. Si hay usuarios con la carrera se rechaza; en ese caso se debe desactivar
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la carrera"
+// @Success 200 {object} map[string]string "Carrera eliminada"
+// @Failure 404 {object} apierror.ErrorResponse "Carrera no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "La carrera tiene usuarios asignados"
+// @Router /admin/carreras/{id} [delete]
+func (h *Handlers) DeleteCarreraHandler(c *gin.Context) {
+	carrera, err := h.findCarrera(c.Param("id"))
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var usuarios int64
+	if err := h.db.WithContext(c.Request.Context()).Model(&models.Usuario{}).Where("id_carrera = ?", carrera.Id_carrera).Count(&usuarios).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	if usuarios > 0 {
+		apierror.Respond(c, apierror.CarreraInUse.WithDetail("usuarios", usuarios))
+		return
+	}
+
+	if err := h.db.WithContext(c.Request.Context()).Delete(carrera).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.carrera_deleted")})
+}
+
+func (h *Handlers) findCarrera(param string) (*models.Carrera, error) {
+	var carrera models.Carrera
+	if err := h.findByID(&carrera, param, apierror.CarreraNotFound); err != nil {
+		return nil, err
+	}
+	return &carrera, nil
+}
+
+// checkCodigoDisponible verifica que ninguna otra carrera use el código
+func (h *Handlers) checkCodigoDisponible(codigo string, excluir uint) error {
+	var existentes int64
+	if err := h.db.Model(&models.Carrera{}).
+		Where("codigo = ? AND id_carrera <> ?", codigo, excluir).
+		Count(&existentes).Error; err != nil {
+		return apierror.DatabaseError.Wrap(err)
+	}
+	if existentes > 0 {
+		return apierror.CarreraCodeTaken
+	}
+	return nil
+}
@@ -0,0 +1,506 @@
+package academico
+
+import (
+	"net/http"
+	"strings"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UniversidadRequest estructura para crear o actualizar una universidad
+type UniversidadRequest struct {
+	Codigo string `json:"Codigo" binding:"required" example:"UTAL"`
+	Nombre string `json:"Nombre" binding:"required" example:"Universidad de Talca"`
+	// Activa es opcional; por defecto queda activa
+	Activa *bool `json:"Activa"`
+}
+
+// FacultadRequest estructura para crear o actualizar una facultad
+type FacultadRequest struct {
+	Id_universidad uint   `json:"Id_universidad" binding:"required" example:"1"`
+	Nombre         string `json:"Nombre" binding:"required" example:"Facultad de Ingeniería"`
+	// Activa es opcional; por defecto queda activa
+	Activa *bool `json:"Activa"`
+}
+
+// SedeRequest estructura para crear o actualizar una sede
+type SedeRequest struct {
+	Id_facultad uint   `json:"Id_facultad" binding:"required" example:"1"`
+	Nombre      string `json:"Nombre" binding:"required" example:"Campus Curicó"`
+	Ciudad      string `json:"Ciudad" example:"Curicó"`
+	// Activa es opcional; por defecto queda activa
+	Activa *bool `json:"Activa"`
+}
+
+// UniversidadNodo es una universidad con sus facultades, sedes y carreras activas
+type UniversidadNodo struct {
+	Id_universidad uint           `json:"Id_universidad"`
+	Codigo         string         `json:"Codigo"`
+	Nombre         string         `json:"Nombre"`
+	Facultades     []FacultadNodo `json:"Facultades"`
+}
+
+// FacultadNodo es una facultad con sus sedes activas
+type FacultadNodo struct {
+	Id_facultad uint       `json:"Id_facultad"`
+	Nombre      string     `json:"Nombre"`
+	Sedes       []SedeNodo `json:"Sedes"`
+}
+
+// SedeNodo es una sede con sus carreras activas
+type SedeNodo struct {
+	Id_sede  uint             `json:"Id_sede"`
+	Nombre   string           `json:"Nombre"`
+	Ciudad   string           `json:"Ciudad"`
+	Carreras []models.Carrera `json:"Carreras"`
+}
+
+// JerarquiaHandler devuelve la jerarquía académica activa para los formularios de registro
+// @Summary Jerarquía académica
+// @Description Devuelve las universidades activas con sus facultades, sedes y carreras activas, para llenar los selectores en cascada del registro
+// @Tags carreras
+// @Produce json
+// @Success 200 {array} academico.UniversidadNodo "Jerarquía académica"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /universidades [get]
+func (h *Handlers) JerarquiaHandler(c *gin.Context) {
+	var universidades []models.Universidad
+	var facultades []models.Facultad
+	var sedes []models.Sede
+	var carreras []models.Carrera
+
+	err := h.db.WithContext(c.Request.Context()).Where("activa = ?", true).Order("nombre").Find(&universidades).Error
+	if err == nil {
+		err = h.db.WithContext(c.Request.Context()).Where("activa = ?", true).Order("nombre").Find(&facultades).Error
+	}
+	if err == nil {
+		err = h.db.WithContext(c.Request.Context()).Where("activa = ?", true).Order("nombre").Find(&sedes).Error
+	}
+	if err == nil {
+		err = h.db.WithContext(c.Request.Context()).Where("activa = ? AND id_sede IS NOT NULL", true).Order("nombre").Find(&carreras).Error
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+
+	carrerasPorSede := map[uint][]models.Carrera{}
+	for _, carrera := range carreras {
+		carrerasPorSede[*carrera.Id_sede] = append(carrerasPorSede[*carrera.Id_sede], carrera)
+	}
+	sedesPorFacultad := map[uint][]SedeNodo{}
+	for _, sede := range sedes {
+		nodo := SedeNodo{Id_sede: sede.Id_sede, Nombre: sede.Nombre, Ciudad: sede.Ciudad, Carreras: carrerasPorSede[sede.Id_sede]}
+		if nodo.Carreras == nil {
+			nodo.Carreras = []models.Carrera{}
+		}
+		sedesPorFacultad[sede.Id_facultad] = append(sedesPorFacultad[sede.Id_facultad], nodo)
+	}
+	facultadesPorUniversidad := map[uint][]FacultadNodo{}
+	for _, facultad := range facultades {
+		nodo := FacultadNodo{Id_facultad: facultad.Id_facultad, Nombre: facultad.Nombre, Sedes: sedesPorFacultad[facultad.Id_facultad]}
+		if nodo.Sedes == nil {
+			nodo.Sedes = []SedeNodo{}
+		}
+		facultadesPorUniversidad[facultad.Id_universidad] = append(facultadesPorUniversidad[facultad.Id_universidad], nodo)
+	}
+
+	arbol := make([]UniversidadNodo, 0, len(universidades))
+	for _, universidad := range universidades {
+		nodo := UniversidadNodo{
+			Id_universidad: universidad.Id_universidad,
+			Codigo:         universidad.Codigo,
+			Nombre:         universidad.Nombre,
+			Facultades:     facultadesPorUniversidad[universidad.Id_universidad],
+		}
+		if nodo.Facultades == nil {
+			nodo.Facultades = []FacultadNodo{}
+		}
+		arbol = append(arbol, nodo)
+	}
+
+	c.JSON(http.StatusOK, arbol)
+}
+
+// ListUniversidadesHandler lista todas las universidades, incluidas las inactivas
+// @Summary Listar universidades
+// @Description Lista todas las universidades, incluidas las inactivas
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} models.Universidad "Universidades"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Router /admin/universidades [get]
+func (h *Handlers) ListUniversidadesHandler(c *gin.Context) {
+	var universidades []models.Universidad
+	if err := h.db.WithContext(c.Request.Context()).Order("nombre").Find(&universidades).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, universidades)
+}
+
+// CreateUniversidadHandler crea una universidad
+// @Summary Crear universidad
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param universidad body UniversidadRequest true "Datos de la universidad"
+// @Success 201 {object} models.Universidad "Universidad creada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos"
+// @Failure 409 {object} apierror.ErrorResponse "El código de universidad ya existe"
+// @Router /admin/universidades [post]
+func (h *Handlers) CreateUniversidadHandler(c *gin.Context) {
+	var req UniversidadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	universidad := models.Universidad{
+		Codigo: strings.ToUpper(strings.TrimSpace(req.Codigo)),
+		Nombre: strings.TrimSpace(req.Nombre),
+		Activa: req.Activa == nil || *req.Activa,
+	}
+	if err := h.checkCodigoUniversidad(universidad.Codigo, 0); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	if err := h.db.WithContext(c.Request.Context()).Create(&universidad).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusCreated, universidad)
+}
+
+// UpdateUniversidadHandler actualiza una universidad
+// @Summary Actualizar universidad
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la universidad"
+// @Param universidad body UniversidadRequest true "Datos de la universidad"
+// @Success 200 {object} models.Universidad "Universidad actualizada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos"
+// @Failure 404 {object} apierror.ErrorResponse "Universidad no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "El código de universidad ya existe"
+// @Router /admin/universidades/{id} [put]
+func (h *Handlers) UpdateUniversidadHandler(c *gin.Context) {
+	var universidad models.Universidad
+	if err := h.findByID(&universidad, c.Param("id"), apierror.UniversidadNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var req UniversidadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	universidad.Codigo = strings.ToUpper(strings.TrimSpace(req.Codigo))
+	universidad.Nombre = strings.TrimSpace(req.Nombre)
+	universidad.Activa = req.Activa == nil || *req.Activa
+	if err := h.checkCodigoUniversidad(universidad.Codigo, universidad.Id_universidad); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	if err := h.db.WithContext(c.Request.Context()).Save(&universidad).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, universidad)
+}
+
+// DeleteUniversidadHandler elimina una universidad sin facultades
+// @Summary Eliminar universidad
+// @Description Elimina una universidad. Si tiene facultades se rechaza; en ese caso se debe desactivar
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la universidad"
+// @Success 200 {object} map[string]string "Universidad eliminada"
+// @Failure 404 {object} apierror.ErrorResponse "Universidad no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "Tiene elementos dependientes"
+// @Router /admin/universidades/{id} [delete]
+func (h *Handlers) DeleteUniversidadHandler(c *gin.Context) {
+	var universidad models.Universidad
+	if err := h.findByID(&universidad, c.Param("id"), apierror.UniversidadNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	h.deleteIfUnused(c, &universidad, &models.Facultad{}, "id_universidad", universidad.Id_universidad)
+}
+
+// ListFacultadesHandler lista las facultades, opcionalmente de una universidad
+// @Summary Listar facultades
+// @Description Lista todas las facultades, incluidas las inactivas
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id_universidad query int false "ID de la universidad"
+// @Success 200 {array} models.Facultad "Facultades"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Router /admin/facultades [get]
+func (h *Handlers) ListFacultadesHandler(c *gin.Context) {
+	query := h.db.WithContext(c.Request.Context()).Order("nombre")
+	if id := c.Query("id_universidad"); id != "" {
+		query = query.Where("id_universidad = ?", id)
+	}
+
+	var facultades []models.Facultad
+	if err := query.Find(&facultades).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, facultades)
+}
+
+// CreateFacultadHandler crea una facultad en una universidad
+// @Summary Crear facultad
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param facultad body FacultadRequest true "Datos de la facultad"
+// @Success 201 {object} models.Facultad "Facultad creada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o universidad inexistente"
+// @Router /admin/facultades [post]
+func (h *Handlers) CreateFacultadHandler(c *gin.Context) {
+	var req FacultadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+	if err := h.checkExists(&models.Universidad{}, req.Id_universidad, "Id_universidad"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	facultad := models.Facultad{
+		Id_universidad: req.Id_universidad,
+		Nombre:         strings.TrimSpace(req.Nombre),
+		Activa:         req.Activa == nil || *req.Activa,
+	}
+	if err := h.db.WithContext(c.Request.Context()).Create(&facultad).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusCreated, facultad)
+}
+
+// UpdateFacultadHandler actualiza una facultad
+// @Summary Actualizar facultad
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la facultad"
+// @Param facultad body FacultadRequest true "Datos de la facultad"
+// @Success 200 {object} models.Facultad "Facultad actualizada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o universidad inexistente"
+// @Failure 404 {object} apierror.ErrorResponse "Facultad no encontrada"
+// @Router /admin/facultades/{id} [put]
+func (h *Handlers) UpdateFacultadHandler(c *gin.Context) {
+	var facultad models.Facultad
+	if err := h.findByID(&facultad, c.Param("id"), apierror.FacultadNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var req FacultadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+	if err := h.checkExists(&models.Universidad{}, req.Id_universidad, "Id_universidad"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	facultad.Id_universidad = req.Id_universidad
+	facultad.Nombre = strings.TrimSpace(req.Nombre)
+	facultad.Activa = req.Activa == nil || *req.Activa
+	if err := h.db.WithContext(c.Request.Context()).Save(&facultad).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, facultad)
+}
+
+// DeleteFacultadHandler elimina una facultad sin sedes
+// @Summary Eliminar facultad
+// @Description Elimina una facultad. Si tiene sedes se rechaza; en ese caso se debe desactivar
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la facultad"
+// @Success 200 {object} map[string]string "Facultad eliminada"
+// @Failure 404 {object} apierror.ErrorResponse "Facultad no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "Tiene elementos dependientes"
+// @Router /admin/facultades/{id} [delete]
+func (h *Handlers) DeleteFacultadHandler(c *gin.Context) {
+	var facultad models.Facultad
+	if err := h.findByID(&facultad, c.Param("id"), apierror.FacultadNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	h.deleteIfUnused(c, &facultad, &models.Sede{}, "id_facultad", facultad.Id_facultad)
+}
+
+// ListSedesHandler lista las sedes, opcionalmente de una facultad
+// @Summary Listar sedes
+// @Description Lista todas las sedes, incluidas las inactivas
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id_facultad query int false "ID de la facultad"
+// @Success 200 {array} models.Sede "Sedes"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Router /admin/sedes [get]
+func (h *Handlers) ListSedesHandler(c *gin.Context) {
+	query := h.db.WithContext(c.Request.Context()).Order("nombre")
+	if id := c.Query("id_facultad"); id != "" {
+		query = query.Where("id_facultad = ?", id)
+	}
+
+	var sedes []models.Sede
+	if err := query.Find(&sedes).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, sedes)
+}
+
+// CreateSedeHandler crea una sede de una facultad
+// @Summary Crear sede
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param sede body SedeRequest true "Datos de la sede"
+// @Success 201 {object} models.Sede "Sede creada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o facultad inexistente"
+// @Router /admin/sedes [post]
+func (h *Handlers) CreateSedeHandler(c *gin.Context) {
+	var req SedeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+	if err := h.checkExists(&models.Facultad{}, req.Id_facultad, "Id_facultad"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	sede := models.Sede{
+		Id_facultad: req.Id_facultad,
+		Nombre:      strings.TrimSpace(req.Nombre),
+		Ciudad:      strings.TrimSpace(req.Ciudad),
+		Activa:      req.Activa == nil || *req.Activa,
+	}
+	if err := h.db.WithContext(c.Request.Context()).Create(&sede).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusCreated, sede)
+}
+
+// UpdateSedeHandler actualiza una sede
+// @Summary Actualizar sede
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la sede"
+// @Param sede body SedeRequest true "Datos de la sede"
+// @Success 200 {object} models.Sede "Sede actualizada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o facultad inexistente"
+// @Failure 404 {object} apierror.ErrorResponse "Sede no encontrada"
+// @Router /admin/sedes/{id} [put]
+func (h *Handlers) UpdateSedeHandler(c *gin.Context) {
+	var sede models.Sede
+	if err := h.findByID(&sede, c.Param("id"), apierror.SedeNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	var req SedeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+	if err := h.checkExists(&models.Facultad{}, req.Id_facultad, "Id_facultad"); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	sede.Id_facultad = req.Id_facultad
+	sede.Nombre = strings.TrimSpace(req.Nombre)
+	sede.Ciudad = strings.TrimSpace(req.Ciudad)
+	sede.Activa = req.Activa == nil || *req.Activa
+	if err := h.db.WithContext(c.Request.Context()).Save(&sede).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, sede)
+}
+
+// DeleteSedeHandler elimina una sede sin carreras
+// @Summary Eliminar sede
+// @Description Elimina una sede. Si tiene carreras se rechaza; en ese caso se debe desactivar
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID de la sede"
+// @Success 200 {object} map[string]string "Sede eliminada"
+// @Failure 404 {object} apierror.ErrorResponse "Sede no encontrada"
+// @Failure 409 {object} apierror.ErrorResponse "Tiene elementos dependientes"
+// @Router /admin/sedes/{id} [delete]
+func (h *Handlers) DeleteSedeHandler(c *gin.Context) {
+	var sede models.Sede
+	if err := h.findByID(&sede, c.Param("id"), apierror.SedeNotFound); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	h.deleteIfUnused(c, &sede, &models.Carrera{}, "id_sede", sede.Id_sede)
+}
+
+// deleteIfUnused elimina el registro si ninguna fila de dependiente lo referencia
+func (h *Handlers) deleteIfUnused(c *gin.Context, registro, dependiente interface{}, column string, id uint) {
+	count, err := h.countDependientes(dependiente, column, id)
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	if count > 0 {
+		apierror.Respond(c, apierror.HierarchyInUse.WithDetail("dependientes", count))
+		return
+	}
+
+	if err := h.db.WithContext(c.Request.Context()).Delete(registro).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.deleted")})
+}
+
+// checkCodigoUniversidad verifica que ninguna otra universidad use el código
+func (h *Handlers) checkCodigoUniversidad(codigo string, excluir uint) error {
+	var existentes int64
+	if err := h.db.Model(&models.Universidad{}).
+		Where("codigo = ? AND id_universidad <> ?", codigo, excluir).
+		Count(&existentes).Error; err != nil {
+		return apierror.DatabaseError.Wrap(err)
+	}
+	if existentes > 0 {
+		return apierror.UniversidadCodeTaken
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+	"login/internal/outbox"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailOutboxStatusHandler muestra el estado de entrega de los correos enviados a un destinatario
+// @Summary Estado de entrega de correos
+// @Description Lista los correos encolados para un destinatario con su estado (pendiente, enviando, enviado, fallido), intentos y último error
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param correo query string true "Correo del destinatario"
+// @Param limit query int false "Cantidad máxima de resultados (por defecto 20)"
+// @Success 200 {array} models.Correo_outbox "Correos del destinatario"
+// @Failure 400 {object} apierror.ErrorResponse "Correo requerido"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /admin/email-outbox [get]
+func (h *Handlers) EmailOutboxStatusHandler(c *gin.Context) {
+	correo := strings.TrimSpace(strings.ToLower(c.Query("correo")))
+	if correo == "" {
+		apierror.Respond(c, apierror.InvalidRequest.WithFields(apierror.Field("correo", "required", "field.required")))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	correos, err := outbox.Status(h.db.WithContext(c.Request.Context()), correo, limit)
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, correos)
+}
+
+// RetryEmailHandler vuelve a encolar un correo descartado para un nuevo ciclo de reintentos
+// @Summary Reintentar un correo fallido
+// @Description Reinicia los intentos de un correo en estado fallido para que el worker lo vuelva a enviar
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "ID del correo"
+// @Success 200 {object} map[string]string "Correo reencolado"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 404 {object} apierror.ErrorResponse "Correo no encontrado o no está fallido"
+// @Router /admin/email-outbox/{id}/retry [post]
+func (h *Handlers) RetryEmailHandler(c *gin.Context) {
+	result := h.db.WithContext(c.Request.Context()).Model(&models.Correo_outbox{}).
+		Where("id = ? AND estado = ?", c.Param("id"), models.EstadoCorreoFallido).
+		Updates(map[string]interface{}{
+			"estado":          models.EstadoCorreoPendiente,
+			"intentos":        0,
+			"proximo_intento": time.Now(),
+		})
+	if result.Error != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(result.Error))
+		return
+	}
+	if result.RowsAffected == 0 {
+		apierror.Respond(c, apierror.OutboxEmailNotFound)
+		return
+	}
+	h.outbox.Wake()
+
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.email_requeued")})
+}
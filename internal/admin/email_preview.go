@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"net/http"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/mailer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailPreviewHandler renderiza una plantilla de correo con datos de ejemplo
+// @Summary Previsualizar plantilla de correo
+// @Description Renderiza una plantilla de correo con datos de ejemplo y la marca de la aplicación indicada
+// @Tags admin
+// @Produce html
+// @Param Authorization header string true "Bearer token"
+// @Param name path string true "Nombre de la plantilla (verification, password_reset, welcome, company_approval, security_alert)"
+// @Param app query string false "Aplicación (ulink, practicas, descuentos, roomies)"
+// @Param lang query string false "Idioma (es, en); por defecto el de Accept-Language"
+// @Param format query string false "Formato (html o text)"
+// @Success 200 {string} string "Plantilla renderizada"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 404 {object} apierror.ErrorResponse "Plantilla no encontrada"
+// @Router /admin/email-templates/{name}/preview [get]
+func EmailPreviewHandler(c *gin.Context) {
+	name := c.Param("name")
+	brand := mailer.BrandFor(c.DefaultQuery("app", mailer.DefaultBrand))
+
+	lang := i18n.Normalize(c.Query("lang"))
+	if lang == "" {
+		lang = i18n.FromContext(c)
+	}
+
+	msg, err := mailer.Render(name, lang, brand, mailer.SampleData(name))
+	if err != nil {
+		apierror.Respond(c, apierror.TemplateNotFound.WithDetail("plantillas", mailer.Templates()))
+		return
+	}
+
+	c.Header("X-Email-Subject", msg.Subject)
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, msg.Text)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(msg.HTML))
+}
@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"login/internal/outbox"
+
+	"gorm.io/gorm"
+)
+
+// Handlers agrupa los endpoints de administración que consultan la base de datos
+type Handlers struct {
+	db     *gorm.DB
+	outbox *outbox.Worker
+}
+
+// NewHandlers crea los handlers de administración
+func NewHandlers(db *gorm.DB, mail *outbox.Worker) *Handlers {
+	return &Handlers{db: db, outbox: mail}
+}
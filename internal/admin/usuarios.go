@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"login/internal/academico"
+	"login/internal/apierror"
+	"login/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UsuariosResponse es una página del listado de usuarios
+type UsuariosResponse struct {
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	Limit    int              `json:"limit"`
+	Usuarios []models.Usuario `json:"usuarios"`
+}
+
+// UsuariosStat es la cantidad de usuarios de un nodo de la jerarquía académica.
+// Id es nulo para los usuarios sin carrera asignada en ese nivel
+type UsuariosStat struct {
+	Id     *uint  `json:"id"`
+	Nombre string `json:"nombre"`
+	Total  int64  `json:"total"`
+}
+
+// ListUsuariosHandler lista los usuarios filtrados por cualquier nivel de la jerarquía académica
+// @Summary Listar usuarios
+// @Description Lista los usuarios con su carrera, filtrados por universidad, facultad, sede o carrera
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id_universidad query int false "ID de la universidad"
+// @Param id_facultad query int false "ID de la facultad"
+// @Param id_sede query int false "ID de la sede"
+// @Param id_carrera query int false "ID de la carrera"
+// @Param page query int false "Página (por defecto 1)"
+// @Param limit query int false "Usuarios por página (por defecto 50, máximo 200)"
+// @Success 200 {object} admin.UsuariosResponse "Usuarios"
+// @Failure 400 {object} apierror.ErrorResponse "Filtro inválido"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Router /admin/usuarios [get]
+func (h *Handlers) ListUsuariosHandler(c *gin.Context) {
+	filtro, err := academico.FiltroFromQuery(c)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	// La sesión permite reutilizar la consulta para el conteo y la página
+	query := filtro.JoinUsuarios(h.db.WithContext(c.Request.Context()).Model(&models.Usuario{})).Session(&gorm.Session{})
+	resp := UsuariosResponse{Page: page, Limit: limit}
+	if err := query.Count(&resp.Total).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	if err := query.Select(`"Usuario".*`).Preload("Carrera").
+		Order(`"Usuario".id`).Limit(limit).Offset((page - 1) * limit).
+		Find(&resp.Usuarios).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UsuariosStatsHandler cuenta los usuarios agrupados por un nivel de la jerarquía académica
+// @Summary Estadísticas de usuarios
+// @Description Cuenta los usuarios agrupados por universidad, facultad, sede o carrera, con los mismos filtros que el listado
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param group_by query string true "Nivel de agrupación (universidad, facultad, sede, carrera)"
+// @Param id_universidad query int false "ID de la universidad"
+// @Param id_facultad query int false "ID de la facultad"
+// @Param id_sede query int false "ID de la sede"
+// @Param id_carrera query int false "ID de la carrera"
+// @Success 200 {array} admin.UsuariosStat "Usuarios por nivel"
+// @Failure 400 {object} apierror.ErrorResponse "Filtro o nivel inválido"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Router /admin/usuarios/stats [get]
+func (h *Handlers) UsuariosStatsHandler(c *gin.Context) {
+	idColumn, nombreColumn, ok := academico.GroupColumns(c.Query("group_by"))
+	if !ok {
+		apierror.Respond(c, apierror.InvalidRequest.WithFields(apierror.Field("group_by", "oneof", "field.invalid")))
+		return
+	}
+
+	filtro, err := academico.FiltroFromQuery(c)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	stats := []UsuariosStat{}
+	err = filtro.JoinUsuarios(h.db.WithContext(c.Request.Context()).Model(&models.Usuario{})).
+		Select(idColumn + " AS id, COALESCE(" + nombreColumn + ", '') AS nombre, COUNT(*) AS total").
+		Group(idColumn + ", " + nombreColumn).
+		Order("total DESC").
+		Scan(&stats).Error
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,255 @@
+package apierror
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"login/internal/i18n"
+	"login/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error es un error de la API con un código estable que los clientes pueden interpretar
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Fields  []FieldError
+	Details map[string]interface{}
+
+	// cause es el error interno; se registra en el log y nunca se envía al cliente
+	cause error
+}
+
+// FieldError describe un problema con un campo específico de la solicitud
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// key y params traducen Message al idioma de la solicitud al responder
+	key    string
+	params []any
+}
+
+// Field crea un FieldError cuyo mensaje se traduce con la clave indicada al responder
+func Field(field, code, key string, params ...any) FieldError {
+	return FieldError{Field: field, Code: code, Message: i18n.T(i18n.Default, key, params...), key: key, params: params}
+}
+
+// Localized es un valor de Details que se traduce al idioma de la solicitud al responder
+type Localized string
+
+// ErrorResponse es el cuerpo de todas las respuestas de error de la API
+type ErrorResponse struct {
+	Error   string                 `json:"error" example:"Datos inválidos"`
+	Code    string                 `json:"code" example:"invalid_request"`
+	Fields  []FieldError           `json:"fields,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+var catalog []*Error
+
+// define registra un código en el catálogo
+func define(code string, status int, message string) *Error {
+	e := &Error{Code: code, Status: status, Message: message}
+	catalog = append(catalog, e)
+	return e
+}
+
+// Catalog devuelve todos los códigos de error definidos
+func Catalog() []*Error {
+	return catalog
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Code + ": " + e.cause.Error()
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is compara por código, de modo que errors.Is funciona con las copias creadas por Wrap o WithFields
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	c.Fields = append([]FieldError(nil), e.Fields...)
+	if e.Details != nil {
+		c.Details = make(map[string]interface{}, len(e.Details))
+		for k, v := range e.Details {
+			c.Details[k] = v
+		}
+	}
+	return &c
+}
+
+// Wrap devuelve una copia del error con la causa interna
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+// WithFields devuelve una copia del error con detalles por campo
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	c := e.clone()
+	c.Fields = append(c.Fields, fields...)
+	return c
+}
+
+// WithDetail devuelve una copia del error con un dato adicional para el cliente
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	c := e.clone()
+	if c.Details == nil {
+		c.Details = map[string]interface{}{}
+	}
+	c.Details[key] = value
+	return c
+}
+
+// Response construye el cuerpo de la respuesta en el idioma indicado; si falta la traducción
+// se usa el mensaje del catálogo
+func (e *Error) Response(lang string) ErrorResponse {
+	resp := ErrorResponse{Error: e.Message, Code: e.Code}
+	if key := "error." + e.Code; i18n.Has(lang, key) || i18n.Has(i18n.Default, key) {
+		resp.Error = i18n.T(lang, key)
+	}
+
+	for _, f := range e.Fields {
+		if f.key != "" {
+			f.Message = i18n.T(lang, f.key, f.params...)
+		}
+		resp.Fields = append(resp.Fields, f)
+	}
+
+	if e.Details != nil {
+		resp.Details = make(map[string]interface{}, len(e.Details))
+		for k, v := range e.Details {
+			if key, ok := v.(Localized); ok {
+				v = i18n.T(lang, string(key))
+			}
+			resp.Details[k] = v
+		}
+	}
+	return resp
+}
+
+// From convierte cualquier error en un *Error; los errores desconocidos se tratan como internos
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return Internal.Wrap(err)
+}
+
+// Respond escribe la respuesta de error y registra la causa interna si existe
+func Respond(c *gin.Context, err error) {
+	apiErr := From(err)
+	c.Set(codeContextKey, apiErr.Code)
+	if apiErr.cause != nil {
+		level := slog.LevelWarn
+		if apiErr.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logging.From(c).Log(c.Request.Context(), level, "Error en la solicitud", "code", apiErr.Code, "error", apiErr)
+	}
+	c.JSON(apiErr.Status, apiErr.Response(i18n.FromContext(c)))
+}
+
+// codeContextKey guarda en el contexto de gin el código del error respondido
+const codeContextKey = "apierror_code"
+
+// CodeFromContext devuelve el código del error respondido en la solicitud, o vacío si no hubo error
+func CodeFromContext(c *gin.Context) string {
+	return c.GetString(codeContextKey)
+}
+
+// Abort responde con el error y detiene la cadena de middlewares
+func Abort(c *gin.Context, err error) {
+	Respond(c, err)
+	c.Abort()
+}
+
+// FromBinding convierte el error de ShouldBindJSON en InvalidRequest con detalle por campo
+func FromBinding(err error) *Error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return InvalidRequest.Wrap(err)
+	}
+
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, Field(fe.Field(), fe.Tag(), fieldMessageKey(fe)))
+	}
+	return InvalidRequest.WithFields(fields...)
+}
+
+func fieldMessageKey(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "email", "min", "max":
+		return "field." + fe.Tag()
+	default:
+		return "field.invalid"
+	}
+}
+
+func init() {
+	// Usar el nombre JSON de los campos en los errores de validación
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+			if name == "" || name == "-" {
+				return f.Name
+			}
+			return name
+		})
+	}
+}
+
+// CheckTranslations verifica que cada código del catálogo tenga mensaje en todos los idiomas
+func CheckTranslations() error {
+	keys := make([]string, 0, len(catalog))
+	for _, e := range catalog {
+		keys = append(keys, "error."+e.Code)
+	}
+	return i18n.CheckKeys(keys)
+}
+
+// CatalogEntry describe un código de error del catálogo
+type CatalogEntry struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// CatalogHandler publica el catálogo de códigos de error
+// @Summary Catálogo de códigos de error
+// @Description Lista todos los códigos de error estables que puede devolver la API con su estado HTTP y mensaje en el idioma de Accept-Language
+// @Tags errors
+// @Produce json
+// @Param Accept-Language header string false "Idioma de los mensajes (es, en)"
+// @Success 200 {array} apierror.CatalogEntry "Códigos de error"
+// @Router /error-codes [get]
+func CatalogHandler(c *gin.Context) {
+	lang := i18n.FromContext(c)
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, e := range catalog {
+		entries = append(entries, CatalogEntry{Code: e.Code, Status: e.Status, Message: i18n.T(lang, "error."+e.Code)})
+	}
+	c.JSON(http.StatusOK, entries)
+}
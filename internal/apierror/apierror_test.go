@@ -0,0 +1,9 @@
+package apierror
+
+import "testing"
+
+func TestCatalogoTraducido(t *testing.T) {
+	if err := CheckTranslations(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,97 @@
+package apierror
+
+import "net/http"
+
+// Errores generales
+var (
+	InvalidRequest  = define("invalid_request", http.StatusBadRequest, "Datos inválidos")
+	RequestTooLarge = define("request_too_large", http.StatusRequestEntityTooLarge, "La solicitud es demasiado grande")
+	Unauthenticated = define("unauthenticated", http.StatusUnauthorized, "Usuario no autenticado")
+	TokenMissing    = define("token_missing", http.StatusUnauthorized, "No se proporcionó token")
+	TokenMalformed  = define("token_malformed", http.StatusUnauthorized, "Token malformado")
+	TokenInvalid    = define("token_invalid", http.StatusUnauthorized, "Token inválido")
+	AdminOnly       = define("admin_only", http.StatusForbidden, "Acceso restringido a administradores")
+	AccountNotFound = define("account_not_found", http.StatusNotFound, "Usuario no encontrado")
+	DatabaseError   = define("database_error", http.StatusInternalServerError, "Error al acceder a la base de datos")
+	Internal        = define("internal_error", http.StatusInternalServerError, "Error interno del servidor")
+)
+
+// Registro
+var (
+	EmailRegisteredAsUser    = define("email_registered_user", http.StatusBadRequest, "El correo ya está registrado como usuario")
+	EmailRegisteredAsCompany = define("email_registered_company", http.StatusBadRequest, "El correo ya está registrado como empresa")
+	EmailAlreadyRegistered   = define("email_already_registered", http.StatusBadRequest, "El correo ya tiene una cuenta; regístrate con su misma contraseña")
+	IdentityProviderError    = define("identity_provider_error", http.StatusBadGateway, "Error al crear usuario en Firebase")
+	RegistrationFailed       = define("registration_failed", http.StatusInternalServerError, "Error al guardar el usuario en la base de datos")
+)
+
+// Inicio de sesión
+var (
+	InvalidCredentials = define("invalid_credentials", http.StatusUnauthorized, "Credenciales incorrectas")
+	UserDisabled       = define("user_disabled", http.StatusForbidden, "La cuenta está deshabilitada")
+	TooManyAttempts    = define("too_many_attempts", http.StatusTooManyRequests, "Demasiados intentos fallidos, inténtalo más tarde")
+	InvalidEmail       = define("invalid_email", http.StatusBadRequest, "El correo no es válido")
+	MissingPassword    = define("missing_password", http.StatusBadRequest, "Contraseña requerida")
+	SignInUnavailable  = define("signin_unavailable", http.StatusBadGateway, "El servicio de autenticación no está disponible")
+	UserNotFound       = define("user_not_found", http.StatusUnauthorized, "Usuario no encontrado")
+	CompanyNotFound    = define("company_not_found", http.StatusUnauthorized, "Empresa no encontrada")
+	EmailNotVerified   = define("email_not_verified", http.StatusForbidden, "Debes verificar tu correo antes de continuar")
+	AccountSuspended   = define("account_suspended", http.StatusForbidden, "La cuenta está suspendida")
+)
+
+// Verificación de correo
+var (
+	VerificationTokenInvalid = define("verification_token_invalid", http.StatusBadRequest, "Token inválido o expirado")
+	VerificationCodeInvalid  = define("verification_code_invalid", http.StatusBadRequest, "Código inválido")
+	VerificationCodeExpired  = define("verification_code_expired", http.StatusBadRequest, "Código expirado, solicita uno nuevo")
+	VerificationCodeLocked   = define("verification_code_locked", http.StatusTooManyRequests, "Demasiados intentos, intenta de nuevo más tarde")
+	VerificationEmailFailed  = define("verification_email_failed", http.StatusInternalServerError, "Error al enviar el correo de verificación")
+	EmailVerificationFailed  = define("email_verification_failed", http.StatusInternalServerError, "Error al actualizar el estado de verificación")
+)
+
+// Contraseñas
+var (
+	PasswordPolicy           = define("password_policy", http.StatusBadRequest, "La contraseña no cumple la política de seguridad")
+	CurrentPasswordIncorrect = define("current_password_incorrect", http.StatusUnauthorized, "Contraseña actual incorrecta")
+	PasswordUpdateFailed     = define("password_update_failed", http.StatusInternalServerError, "Error al actualizar la contraseña")
+	ResetTokenInvalid        = define("reset_token_invalid", http.StatusBadRequest, "El enlace de recuperación es inválido o expiró")
+	ResetEmailFailed         = define("reset_email_failed", http.StatusInternalServerError, "Error al enviar el correo de recuperación")
+)
+
+// Perfil y archivos
+var (
+	ProfileUpdateFailed = define("profile_update_failed", http.StatusInternalServerError, "Error al actualizar el perfil")
+	FileMissing         = define("file_missing", http.StatusBadRequest, "No se ha proporcionado un archivo")
+	UploadFailed        = define("upload_failed", http.StatusInternalServerError, "Error al subir la imagen")
+	ProfilePhotoFailed  = define("profile_photo_update_failed", http.StatusInternalServerError, "Error al actualizar la foto de perfil en la base de datos")
+	UnsupportedLanguage = define("unsupported_language", http.StatusBadRequest, "Idioma no soportado")
+)
+
+// Catálogo académico
+var (
+	CarreraInvalid       = define("carrera_invalid", http.StatusBadRequest, "La carrera no existe o no está activa")
+	CarreraNotFound      = define("carrera_not_found", http.StatusNotFound, "Carrera no encontrada")
+	CarreraCodeTaken     = define("carrera_code_taken", http.StatusConflict, "Ya existe una carrera con ese código")
+	CarreraInUse         = define("carrera_in_use", http.StatusConflict, "La carrera tiene usuarios asignados; desactívala en lugar de eliminarla")
+	UniversidadNotFound  = define("universidad_not_found", http.StatusNotFound, "Universidad no encontrada")
+	UniversidadCodeTaken = define("universidad_code_taken", http.StatusConflict, "Ya existe una universidad con ese código")
+	FacultadNotFound     = define("facultad_not_found", http.StatusNotFound, "Facultad no encontrada")
+	SedeNotFound         = define("sede_not_found", http.StatusNotFound, "Sede no encontrada")
+	HierarchyInUse       = define("hierarchy_in_use", http.StatusConflict, "Tiene elementos dependientes; desactívalo en lugar de eliminarlo")
+)
+
+// Idempotencia
+var (
+	IdempotencyKeyTooLong    = define("idempotency_key_too_long", http.StatusBadRequest, "Idempotency-Key demasiado larga")
+	IdempotencyKeyReused     = define("idempotency_key_reused", http.StatusUnprocessableEntity, "La Idempotency-Key ya se usó con una solicitud distinta")
+	IdempotencyKeyInProgress = define("idempotency_in_progress", http.StatusConflict, "Hay una solicitud con la misma Idempotency-Key en proceso")
+)
+
+// Administración
+var (
+	TemplateNotFound     = define("template_not_found", http.StatusNotFound, "Plantilla no encontrada")
+	OutboxEmailNotFound  = define("outbox_email_not_found", http.StatusNotFound, "Correo no encontrado o no está fallido")
+	CannotSuspendSelf    = define("cannot_suspend_self", http.StatusBadRequest, "No puedes suspender tu propia cuenta")
+	SuspensionNotFound   = define("suspension_not_found", http.StatusNotFound, "La cuenta no tiene una suspensión activa")
+	IdentityUpdateFailed = define("identity_update_failed", http.StatusBadGateway, "Error al actualizar la cuenta en Firebase")
+)
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"login/internal/academico"
+	"login/internal/admin"
+	"login/internal/auth"
+	"login/internal/health"
+	"login/internal/idempotency"
+	"login/internal/mailer"
+	"login/internal/metrics"
+	"login/internal/outbox"
+	"login/internal/password"
+	"login/internal/repository"
+	"login/internal/storage"
+	"login/internal/upload"
+	"login/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Deps son los servicios externos de la aplicación. main construye las implementaciones reales;
+// las pruebas pueden usar las de memoria (auth.MemoryIdentity, mailer.MemoryMailer, storage.MemoryStorage)
+type Deps struct {
+	DB       *gorm.DB
+	Identity auth.IdentityProvider
+	Mailer   mailer.Mailer
+	Storage  storage.Storage
+
+	// Usuarios y Empresas son opcionales; por defecto se usan los repositorios GORM sobre DB
+	Usuarios repository.UsuarioRepository
+	Empresas repository.EmpresaRepository
+}
+
+// App es el servidor de la API: registra las rutas sobre sus dependencias y administra los
+// procesos en segundo plano
+type App struct {
+	cfg  config.Config
+	deps Deps
+
+	auth        *auth.Service
+	academico   *academico.Handlers
+	admin       *admin.Handlers
+	upload      *upload.Handler
+	idempotency *idempotency.Store
+	health      *health.Handler
+
+	outboxWorker        *outbox.Worker
+	idempotencyPurger   *idempotency.Purger
+	suspensionScheduler *auth.SuspensionScheduler
+
+	router *gin.Engine
+}
+
+// New construye la aplicación a partir de la configuración y sus dependencias
+func New(cfg config.Config, deps Deps) *App {
+	if deps.Usuarios == nil {
+		deps.Usuarios = repository.NewGormUsuarios(deps.DB)
+	}
+	if deps.Empresas == nil {
+		deps.Empresas = repository.NewGormEmpresas(deps.DB)
+	}
+
+	worker := outbox.NewWorker(deps.DB, deps.Mailer)
+	a := &App{
+		cfg:          cfg,
+		deps:         deps,
+		auth:         auth.NewService(deps.DB, auth.Instrument(deps.Identity), deps.Usuarios, deps.Empresas, worker, authOptions(cfg)),
+		academico:    academico.NewHandlers(deps.DB),
+		admin:        admin.NewHandlers(deps.DB, worker),
+		upload:       upload.NewHandler(deps.Usuarios, deps.Storage),
+		idempotency:  idempotency.NewStore(deps.DB, cfg.IdempotencyTTL),
+		outboxWorker: worker,
+	}
+	a.health = newHealth(deps)
+	a.idempotencyPurger = idempotency.NewPurger(a.idempotency)
+	a.suspensionScheduler = auth.NewSuspensionScheduler(a.auth)
+	a.router = a.routes()
+	return a
+}
+
+// authOptions traslada al servicio de autenticación los ajustes de verificación y de contraseñas
+func authOptions(cfg config.Config) auth.Options {
+	return auth.Options{
+		SecretKey:          []byte(cfg.JWTSecret),
+		BaseURL:            cfg.BaseURL,
+		VerificationMethod: cfg.Verification.Method,
+		VerificationPolicy: cfg.Verification.Policy,
+		VerificationGrace:  time.Duration(cfg.Verification.GraceDays) * 24 * time.Hour,
+		PasswordPolicy: password.Policy{
+			MinLength:          cfg.Password.MinLength,
+			MaxLength:          cfg.Password.MaxLength,
+			RequireUpper:       cfg.Password.RequireUpper,
+			RequireLower:       cfg.Password.RequireLower,
+			RequireDigit:       cfg.Password.RequireDigit,
+			RequireSymbol:      cfg.Password.RequireSymbol,
+			RejectPersonalInfo: cfg.Password.RejectPersonalInfo,
+			RejectBreached:     cfg.Password.RejectBreached,
+		},
+	}
+}
+
+// newHealth registra las verificaciones de /readyz; solo la base de datos es obligatoria. Las
+// dependencias que no implementan health.Pinger, como las implementaciones en memoria, no se verifican
+func newHealth(deps Deps) *health.Handler {
+	h := health.NewHandler()
+	if deps.DB != nil {
+		h.Add("postgres", health.CheckerFunc(func(ctx context.Context) error {
+			sqlDB, err := deps.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}), 0)
+	}
+	for _, dep := range []struct {
+		name    string
+		service interface{}
+	}{
+		{"firebase", deps.Identity},
+		{"storage", deps.Storage},
+		{"smtp", deps.Mailer},
+	} {
+		if pinger, ok := dep.service.(health.Pinger); ok {
+			// Los servicios externos se consultan como máximo una vez por minuto. Son opcionales:
+			// sin ellos fallan algunas funciones, pero sacar todos los pods del balanceador las
+			// dejaría a todas fuera de servicio
+			h.AddOptional(dep.name, health.Cached(health.CheckerFunc(pinger.Ping), time.Minute), 5*time.Second)
+		}
+	}
+	return h
+}
+
+// Router devuelve el router HTTP de la API, utilizable también con httptest
+func (a *App) Router() *gin.Engine {
+	return a.router
+}
+
+// Run inicia los procesos en segundo plano y atiende solicitudes HTTP hasta que ctx se cancela.
+// Entonces deja de aceptar conexiones, espera las solicitudes en curso hasta ShutdownTimeout,
+// detiene los procesos en segundo plano y cierra el pool de la base de datos
+func (a *App) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              a.cfg.Server.Addr,
+		Handler:           a.router,
+		ReadTimeout:       a.cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: a.cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      a.cfg.Server.WriteTimeout,
+		IdleTimeout:       a.cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    a.cfg.Server.MaxHeaderBytes,
+	}
+
+	metricsSrv := &http.Server{
+		Addr:              a.cfg.Server.MetricsAddr,
+		Handler:           metrics.Handler(),
+		ReadHeaderTimeout: a.cfg.Server.ReadHeaderTimeout,
+	}
+
+	// Los procesos en segundo plano no dependen de ctx para seguir entregando lo que encolen
+	// las solicitudes que terminan durante el apagado
+	a.Start(context.Background())
+
+	serveErr := make(chan error, 2)
+	go func() {
+		slog.Info("Servidor escuchando", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+	go func() {
+		slog.Info("Métricas publicadas en /metrics", "addr", metricsSrv.Addr)
+		if err := metricsSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("servidor de métricas: %w", err)
+		}
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+		// El servidor no pudo iniciar, por ejemplo porque el puerto está en uso
+	case <-ctx.Done():
+		slog.Info("Apagando: esperando las solicitudes en curso", "timeout", a.cfg.Server.ShutdownTimeout.String())
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err = srv.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("Apagando: quedaron solicitudes sin terminar", "error", err)
+			srv.Close()
+		}
+	}
+
+	metricsSrv.Close()
+	a.Stop()
+	if a.deps.DB != nil {
+		if sqlDB, dbErr := a.deps.DB.DB(); dbErr == nil {
+			sqlDB.Close()
+		}
+	}
+	slog.Info("Apagado completo")
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Start inicia los procesos en segundo plano: la entrega de correos de la bandeja de salida,
+// la limpieza de claves de idempotencia y el levantamiento de suspensiones vencidas
+func (a *App) Start(ctx context.Context) {
+	a.outboxWorker.Start(ctx)
+	a.idempotencyPurger.Start(ctx)
+	a.suspensionScheduler.Start(ctx)
+}
+
+// Stop detiene los procesos en segundo plano y espera a que terminen
+func (a *App) Stop() {
+	a.suspensionScheduler.Stop()
+	a.idempotencyPurger.Stop()
+	a.outboxWorker.Stop()
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"login/internal/auth"
+	"login/internal/mailer"
+	"login/internal/models"
+	"login/internal/repository"
+	"login/internal/storage"
+	"login/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// testApp es la aplicación armada con las dependencias en memoria. La base SQLite guarda lo que
+// no tiene implementación en memoria: códigos de verificación, bandeja de salida e idempotencia
+type testApp struct {
+	*App
+	identity *auth.MemoryIdentity
+	mailer   *mailer.MemoryMailer
+}
+
+func newTestApp(t *testing.T, cfg config.Config) *testApp {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cada conexión a :memory: es una base distinta; con una sola todas ven el mismo esquema
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(
+		&models.Universidad{}, &models.Facultad{}, &models.Sede{}, &models.Carrera{},
+		&models.Usuario{}, &models.Usuario_empresa{}, &models.Codigo_verificacion{},
+		&models.Correo_outbox{}, &models.Idempotencia{}, &models.Reset_contrasena{}, &models.Suspension_cuenta{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.JWTSecret = "secreto-de-prueba"
+
+	identity := auth.NewMemoryIdentity()
+	outbox := &mailer.MemoryMailer{From: "no-reply@utalca.cl"}
+	a := New(cfg, Deps{
+		DB:       db,
+		Identity: identity,
+		Mailer:   outbox,
+		Storage:  storage.NewMemoryStorage(),
+		Usuarios: repository.NewMemoryUsuarios(),
+	})
+	a.Start(context.Background())
+	t.Cleanup(func() {
+		a.Stop()
+		sqlDB.Close()
+	})
+	return &testApp{App: a, identity: identity, mailer: outbox}
+}
+
+// do envía una solicitud JSON al router y decodifica la respuesta en out si no es nil
+func (a *testApp) do(t *testing.T, method, path, token string, body, out interface{}) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: respuesta no es JSON: %s", method, path, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+// waitMessage espera a que el worker de la bandeja de salida entregue un correo a la dirección
+func (a *testApp) waitMessage(t *testing.T, to string) mailer.Message {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, msg := range a.mailer.Messages() {
+			if len(msg.To) == 1 && msg.To[0] == to {
+				return msg
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no se entregó ningún correo a %s", to)
+	return mailer.Message{}
+}
+
+var codigoRegexp = regexp.MustCompile(`\b\d{6}\b`)
+
+func TestRegistroVerificacionYLogin(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Verification.Method = "code"
+	cfg.Verification.Policy = "strict"
+
+	a := newTestApp(t, cfg)
+	const (
+		email    = "ana.perez@alumnos.utalca.cl"
+		password = "Maule#Lircay-2024"
+	)
+	credenciales := auth.LoginRequest{Email: email, Password: password}
+
+	var registro auth.RegisterResponse
+	status := a.do(t, http.MethodPost, "/register/user", "", auth.RegisterRequest{
+		Email: email, Password: password, Nombres: "Ana", Apellidos: "Pérez",
+	}, &registro)
+	if status != http.StatusOK || registro.FirebaseUID == "" {
+		t.Fatalf("registro: status = %d, respuesta = %+v", status, registro)
+	}
+
+	t.Run("el correo duplicado se rechaza", func(t *testing.T) {
+		var errResp struct{ Code string }
+		status := a.do(t, http.MethodPost, "/register/user", "", auth.RegisterRequest{
+			Email: email, Password: password, Nombres: "Ana", Apellidos: "Pérez",
+		}, &errResp)
+		if status != http.StatusBadRequest || errResp.Code != "email_registered_user" {
+			t.Errorf("status = %d, code = %q", status, errResp.Code)
+		}
+	})
+
+	t.Run("sin verificar no se puede iniciar sesión", func(t *testing.T) {
+		var errResp struct{ Code string }
+		status := a.do(t, http.MethodPost, "/login/user", "", credenciales, &errResp)
+		if status != http.StatusForbidden || errResp.Code != "email_not_verified" {
+			t.Errorf("status = %d, code = %q", status, errResp.Code)
+		}
+	})
+
+	msg := a.waitMessage(t, email)
+	code := codigoRegexp.FindString(msg.Text)
+	if code == "" {
+		t.Fatalf("el correo de verificación no contiene un código:\n%s", msg.Text)
+	}
+
+	t.Run("un código incorrecto no verifica el correo", func(t *testing.T) {
+		incorrecto := "000000"
+		if code == incorrecto {
+			incorrecto = "111111"
+		}
+		var errResp struct{ Code string }
+		status := a.do(t, http.MethodPost, "/verify-email/code", "", auth.VerifyCodeRequest{Email: email, Code: incorrecto}, &errResp)
+		if status != http.StatusBadRequest || errResp.Code != "verification_code_invalid" {
+			t.Errorf("status = %d, code = %q", status, errResp.Code)
+		}
+	})
+
+	if status := a.do(t, http.MethodPost, "/verify-email/code", "", auth.VerifyCodeRequest{Email: email, Code: code}, nil); status != http.StatusOK {
+		t.Fatalf("verificación: status = %d", status)
+	}
+
+	var login auth.LoginResponse
+	if status := a.do(t, http.MethodPost, "/login/user", "", credenciales, &login); status != http.StatusOK {
+		t.Fatalf("login: status = %d", status)
+	}
+	if login.UID != registro.FirebaseUID || !login.EmailVerified {
+		t.Errorf("login = %+v, se esperaba el UID %s con el correo verificado", login, registro.FirebaseUID)
+	}
+	if login.Token != a.identity.TokenFor(registro.FirebaseUID) {
+		t.Errorf("token = %q, no corresponde a la cuenta registrada", login.Token)
+	}
+
+	t.Run("el token da acceso a las rutas protegidas", func(t *testing.T) {
+		if status := a.do(t, http.MethodGet, "/profile-status", login.Token, nil, nil); status != http.StatusOK {
+			t.Errorf("status = %d", status)
+		}
+	})
+
+	t.Run("una contraseña incorrecta se rechaza", func(t *testing.T) {
+		var errResp struct{ Code string }
+		status := a.do(t, http.MethodPost, "/login/user", "", auth.LoginRequest{Email: email, Password: "Incorrecta#2024"}, &errResp)
+		if status != http.StatusUnauthorized || errResp.Code != "invalid_credentials" {
+			t.Errorf("status = %d, code = %q", status, errResp.Code)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"time"
+
+	"login/internal/admin"
+	"login/internal/apierror"
+	"login/internal/buildinfo"
+	"login/internal/i18n"
+	"login/internal/idempotency"
+	"login/internal/logging"
+	"login/internal/metrics"
+	"login/internal/models"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// routes registra las rutas de la API con los handlers de la aplicación
+func (a *App) routes() *gin.Engine {
+	router := gin.New()
+	router.Use(
+		otelgin.Middleware(a.cfg.Tracing.ServiceName),
+		logging.Middleware("/healthz", "/readyz"),
+		metrics.Middleware,
+		i18n.Middleware,
+		gin.CustomRecoveryWithWriter(io.Discard, recoverPanic),
+	)
+
+	// Configurar CORS
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     a.cfg.CORSOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "Accept-Language", "X-App", idempotency.HeaderKey, logging.HeaderRequestID},
+		ExposeHeaders:    []string{"Content-Length", "Authorization", "Content-Language", idempotency.HeaderReplayed, logging.HeaderRequestID},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+
+	router.GET("/healthz", a.health.Healthz)
+	router.GET("/readyz", a.health.Readyz)
+	router.GET("/version", buildinfo.Handler)
+	router.GET("/error-codes", apierror.CatalogHandler)
+	router.GET("/carreras", a.academico.ListCarrerasHandler)
+	router.GET("/universidades", a.academico.JerarquiaHandler)
+	router.POST("/register/user", a.idempotency.Middleware, metrics.Count(metrics.Registrations, models.TipoCuentaUsuario), a.auth.RegisterHandler)
+	router.POST("/login/user", metrics.Count(metrics.Logins, models.TipoCuentaUsuario), a.auth.UserLoginHandler)
+	router.POST("/register_empresa", a.idempotency.Middleware, metrics.Count(metrics.Registrations, models.TipoCuentaEmpresa), a.auth.RegisterHandler_empresa)
+	router.POST("/login/company", metrics.Count(metrics.Logins, models.TipoCuentaEmpresa), a.auth.CompanyLoginHandler)
+	router.GET("/verify-email", metrics.Count(metrics.Verifications, "link"), a.auth.VerifyEmailHandler)
+	router.POST("/verify-email/code", metrics.Count(metrics.Verifications, "code"), a.auth.VerifyEmailCodeHandler)
+	router.POST("/password-reset", a.idempotency.Middleware, a.auth.SendPasswordResetEmailHandler)
+	router.POST("/password-reset/confirm", a.auth.ConfirmPasswordResetHandler)
+	router.POST("/resend-verification", a.idempotency.Middleware, a.auth.ResendVerificationEmailHandler)
+
+	// Rutas protegidas
+	protected := router.Group("/").Use(a.auth.AuthMiddleware) // Agrupar las rutas protegidas con el middleware
+	{
+		protected.POST("/complete-profile", a.auth.RequireVerifiedEmail, a.auth.CompleteProfileHandler)                                                     // Ruta para completar perfil
+		protected.POST("/complete-profile/empresa", a.auth.RequireVerifiedEmail, a.auth.CompleteProfileEmpresaHandler)                                      // Ruta para completar perfil
+		protected.POST("/upload-image", a.auth.RequireVerifiedEmail, a.idempotency.Middleware, metrics.Count(metrics.Uploads), a.upload.UploadImageHandler) // Ruta para subir imágenes
+		protected.GET("/profile-status", a.auth.GetProfileStatusHandler)                                                                                    // Ruta para ver si el perfil esta verificado
+		protected.GET("/profile-status-empresa", a.auth.GetProfileStatusEmpresaHandler)                                                                     // Ruta para ver si el perfil esta verificado
+		protected.POST("/change-password", a.auth.ChangePasswordHandler)                                                                                    // Ruta para cambiar la contraseña
+		protected.PUT("/language", a.auth.UpdateLanguageHandler)                                                                                            // Ruta para guardar el idioma preferido
+
+	}
+
+	// Rutas de administración
+	adminRoutes := router.Group("/admin").Use(a.auth.AuthMiddleware, a.auth.AdminMiddleware)
+	{
+		adminRoutes.GET("/email-templates/:name/preview", admin.EmailPreviewHandler)    // Ruta para previsualizar plantillas de correo
+		adminRoutes.GET("/email-outbox", a.admin.EmailOutboxStatusHandler)              // Ruta para ver el estado de entrega de correos
+		adminRoutes.POST("/email-outbox/:id/retry", a.admin.RetryEmailHandler)          // Ruta para reintentar un correo fallido
+		adminRoutes.GET("/carreras", a.academico.AdminListCarrerasHandler)              // Ruta para listar todas las carreras
+		adminRoutes.POST("/carreras", a.academico.CreateCarreraHandler)                 // Ruta para crear una carrera
+		adminRoutes.PUT("/carreras/:id", a.academico.UpdateCarreraHandler)              // Ruta para actualizar una carrera
+		adminRoutes.DELETE("/carreras/:id", a.academico.DeleteCarreraHandler)           // Ruta para eliminar una carrera
+		adminRoutes.GET("/universidades", a.academico.ListUniversidadesHandler)         // Ruta para listar universidades
+		adminRoutes.POST("/universidades", a.academico.CreateUniversidadHandler)        // Ruta para crear una universidad
+		adminRoutes.PUT("/universidades/:id", a.academico.UpdateUniversidadHandler)     // Ruta para actualizar una universidad
+		adminRoutes.DELETE("/universidades/:id", a.academico.DeleteUniversidadHandler)  // Ruta para eliminar una universidad
+		adminRoutes.GET("/facultades", a.academico.ListFacultadesHandler)               // Ruta para listar facultades
+		adminRoutes.POST("/facultades", a.academico.CreateFacultadHandler)              // Ruta para crear una facultad
+		adminRoutes.PUT("/facultades/:id", a.academico.UpdateFacultadHandler)           // Ruta para actualizar una facultad
+		adminRoutes.DELETE("/facultades/:id", a.academico.DeleteFacultadHandler)        // Ruta para eliminar una facultad
+		adminRoutes.GET("/sedes", a.academico.ListSedesHandler)                         // Ruta para listar sedes
+		adminRoutes.POST("/sedes", a.academico.CreateSedeHandler)                       // Ruta para crear una sede
+		adminRoutes.PUT("/sedes/:id", a.academico.UpdateSedeHandler)                    // Ruta para actualizar una sede
+		adminRoutes.DELETE("/sedes/:id", a.academico.DeleteSedeHandler)                 // Ruta para eliminar una sede
+		adminRoutes.GET("/usuarios", a.admin.ListUsuariosHandler)                       // Ruta para listar usuarios por jerarquía académica
+		adminRoutes.GET("/usuarios/stats", a.admin.UsuariosStatsHandler)                // Ruta para contar usuarios por jerarquía académica
+		adminRoutes.POST("/accounts/:uid/suspend", a.auth.SuspendAccountHandler)        // Ruta para suspender una cuenta
+		adminRoutes.POST("/accounts/:uid/unsuspend", a.auth.UnsuspendAccountHandler)    // Ruta para levantar la suspensión de una cuenta
+		adminRoutes.GET("/accounts/:uid/suspensions", a.auth.AccountSuspensionsHandler) // Ruta para ver el historial de suspensiones
+	}
+
+	return router
+}
+
+// recoverPanic registra el pánico con la pila en el log de la solicitud y responde un error interno
+func recoverPanic(c *gin.Context, err any) {
+	logging.From(c).Error("Pánico atendiendo la solicitud", "panic", fmt.Sprint(err), "stack", string(debug.Stack()))
+	apierror.Abort(c, apierror.Internal)
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"login/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RolAdmin es el rol de los usuarios con acceso a las rutas de administración
+const RolAdmin = "admin"
+
+// AdminMiddleware permite el acceso solo a usuarios con rol administrador.
+// Debe usarse después de AuthMiddleware
+func (s *Service) AdminMiddleware(c *gin.Context) {
+	uid, exists := c.Get("uid")
+	if !exists {
+		apierror.Abort(c, apierror.Unauthenticated)
+		return
+	}
+
+	usuario, err := s.usuarios.FindByFirebaseUID(c.Request.Context(), uid.(string))
+	if err != nil || usuario.Rol != RolAdmin {
+		apierror.Abort(c, apierror.AdminOnly)
+		return
+	}
+
+	c.Next()
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"login/internal/mailer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// appFromRequest identifica la aplicación hermana desde la que llega la solicitud
+// usando el encabezado X-App o, en su defecto, el Origin/Referer
+func appFromRequest(c *gin.Context) string {
+	if app := c.GetHeader("X-App"); app != "" {
+		return app
+	}
+	if app := mailer.AppFromOrigin(c.GetHeader("Origin")); app != "" {
+		return app
+	}
+	return mailer.AppFromOrigin(c.GetHeader("Referer"))
+}
@@ -2,40 +2,71 @@ package auth
 
 import (
 	"context"
-	"net/http"
 	"strings"
 
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/logging"
+
 	"github.com/gin-gonic/gin"
 )
 
 // AuthMiddleware verifica el token JWT
-func AuthMiddleware(c *gin.Context) {
+func (s *Service) AuthMiddleware(c *gin.Context) {
 	// Obtener el token del encabezado Authorization
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "No se proporcionó token"})
-		c.Abort()
+		apierror.Abort(c, apierror.TokenMissing)
 		return
 	}
 
 	// Eliminar "Bearer " del token
 	idToken := strings.TrimPrefix(authHeader, "Bearer ")
 	if idToken == authHeader {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token malformado"})
-		c.Abort()
+		apierror.Abort(c, apierror.TokenMalformed)
 		return
 	}
 
-	// Verificar el token con Firebase
-	ctx := context.Background()
-	token, err := authClient.VerifyIDToken(ctx, idToken)
+	// Verificar el token con el proveedor de identidad
+	token, err := s.identity.VerifyIDToken(c.Request.Context(), idToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido"})
-		c.Abort()
+		apierror.Abort(c, apierror.TokenInvalid)
 		return
 	}
 
 	// Guardar el UID del usuario en el contexto para usarlo en otras rutas
 	c.Set("uid", token.UID)
+	c.Set("email_verified", token.EmailVerified)
+	logging.With(c, "uid", token.UID)
+
+	settings := s.loadAccountSettings(c.Request.Context(), token.UID)
+
+	// La preferencia de idioma guardada tiene prioridad sobre Accept-Language
+	if lang := i18n.Normalize(settings.Idioma); lang != "" {
+		i18n.SetLanguage(c, lang)
+	}
+
+	// Los tokens emitidos antes de una suspensión siguen siendo válidos hasta que expiran
+	if settings.Suspendido {
+		apierror.Abort(c, s.suspensionError(token.UID))
+		return
+	}
 	c.Next() // Continuar la ejecución de la ruta
 }
+
+// accountSettings son los datos de la cuenta que el middleware aplica a cada solicitud
+type accountSettings struct {
+	Idioma     string
+	Suspendido bool
+}
+
+// loadAccountSettings obtiene el idioma y la suspensión del usuario o la empresa
+func (s *Service) loadAccountSettings(ctx context.Context, uid string) accountSettings {
+	if usuario, err := s.usuarios.FindByFirebaseUID(ctx, uid); err == nil {
+		return accountSettings{Idioma: usuario.Idioma, Suspendido: usuario.Suspendido}
+	}
+	if empresa, err := s.empresas.FindByFirebaseUID(ctx, uid); err == nil {
+		return accountSettings{Idioma: empresa.Idioma, Suspendido: empresa.Suspendido}
+	}
+	return accountSettings{}
+}
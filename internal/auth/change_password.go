@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/logging"
+	"login/internal/password"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangePasswordRequest estructura para cambiar la contraseña
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ChangePasswordHandler permite al usuario autenticado cambiar su contraseña
+// @Summary Cambiar contraseña
+// @Description Verifica la contraseña actual y la reemplaza por una nueva que cumpla la política de seguridad
+// @Tags password
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param password body ChangePasswordRequest true "Contraseña actual y nueva"
+// @Success 200 {object} SuccessResponse "Contraseña actualizada correctamente"
+// @Failure 400 {object} apierror.ErrorResponse "La contraseña no cumple la política de seguridad"
+// @Failure 401 {object} apierror.ErrorResponse "Contraseña actual incorrecta"
+// @Failure 500 {object} apierror.ErrorResponse "Error al actualizar la contraseña"
+// @Router /change-password [post]
+func (s *Service) ChangePasswordHandler(c *gin.Context) {
+	uid, exists := c.Get("uid")
+	if !exists {
+		apierror.Respond(c, apierror.Unauthenticated)
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	userRecord, err := s.identity.GetUser(c.Request.Context(), uid.(string))
+	if err != nil {
+		apierror.Respond(c, apierror.SignInUnavailable.Wrap(err))
+		return
+	}
+
+	// Verificar la contraseña actual
+	if _, err := s.identity.SignIn(c.Request.Context(), userRecord.Email, req.CurrentPassword); err != nil {
+		if errors.Is(err, apierror.InvalidCredentials) {
+			err = apierror.CurrentPasswordIncorrect
+		}
+		apierror.Respond(c, err)
+		return
+	}
+
+	if !s.checkPasswordPolicy(c, req.NewPassword, s.userInfoForUID(c.Request.Context(), uid.(string), userRecord.Email)) {
+		return
+	}
+
+	err = s.identity.SetPassword(c.Request.Context(), userRecord.UID, req.NewPassword)
+	if err != nil {
+		apierror.Respond(c, apierror.PasswordUpdateFailed.Wrap(err))
+		return
+	}
+
+	// Avisar al usuario del cambio por correo
+	if err := sendSecurityAlert(s.db.WithContext(c.Request.Context()), userRecord.Email, appFromRequest(c), i18n.FromContext(c), "event.password_changed", c.ClientIP()); err != nil {
+		logging.From(c).Error("Cambio de contraseña: error encolando la alerta", "error", err)
+	} else {
+		s.outbox.Wake()
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.password_changed")})
+}
+
+// userInfoForUID obtiene los datos personales del usuario o empresa para validar la contraseña
+func (s *Service) userInfoForUID(ctx context.Context, uid, email string) password.UserInfo {
+	info := password.UserInfo{Email: email}
+
+	if usuario, err := s.usuarios.FindByFirebaseUID(ctx, uid); err == nil {
+		info.Nombres = usuario.Nombres
+		info.Apellidos = usuario.Apellidos
+		return info
+	}
+
+	if empresa, err := s.empresas.FindByFirebaseUID(ctx, uid); err == nil {
+		info.Nombres = empresa.Nombre_empresa
+	}
+	return info
+}
@@ -1,10 +1,13 @@
 package auth
 
 import (
-	"login/internal/database"
-	"login/internal/models"
+	"errors"
 	"net/http"
 
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/repository"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,40 +30,42 @@ type ProfileUpdateRequests struct {
 // @Param Authorization header string true "Bearer token"
 // @Param profile body ProfileUpdateRequest true "Datos para actualizar el perfil"
 // @Success 200 {object} SuccessResponse "Perfil actualizado correctamente"
-// @Failure 400 {object} ErrorResponse "Datos inválidos"
-// @Failure 401 {object} ErrorResponse "Usuario no autenticado"
-// @Failure 500 {object} ErrorResponse "Error al actualizar el perfil"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Failure 500 {object} apierror.ErrorResponse "Error al actualizar el perfil"
 // @Router /complete-profile/empresa [post]
-func CompleteProfileEmpresaHandler(c *gin.Context) {
+func (s *Service) CompleteProfileEmpresaHandler(c *gin.Context) {
 	uid, exists := c.Get("uid")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		apierror.Respond(c, apierror.Unauthenticated)
 		return
 	}
 
 	// Obtener los datos del perfil desde el cuerpo de la solicitud
 	var req ProfileUpdateRequests
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos"})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
 	// Actualizar solo los campos no relacionados con la foto de perfil
-	var empresa models.Usuario_empresa
-	result := database.DB.Model(&empresa).Where("firebase_usuario_empresa = ?", uid).Updates(models.Usuario_empresa{
+	err := s.empresas.UpdatePerfil(c.Request.Context(), uid.(string), repository.PerfilEmpresa{
 		Sector:            req.Sector,
 		Descripcion:       req.Descripcion,
 		Direccion:         req.Direccion,
 		Persona_contacto:  req.Persona_contacto,
 		Correo_contacto:   req.Correo_contacto,
 		Telefono_contacto: req.Telefono_contacto,
-		Perfil_Completado: true,
 	})
-
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar el perfil"})
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Respond(c, apierror.AccountNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.ProfileUpdateFailed.Wrap(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Perfil actualizado correctamente"})
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.profile_updated")})
 }
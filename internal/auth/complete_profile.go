@@ -1,16 +1,21 @@
 package auth
 
 import (
-	"login/internal/database"
-	"login/internal/models"
+	"errors"
 	"net/http"
 
+	"login/internal/academico"
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/perfil"
+	"login/internal/repository"
+
 	"github.com/gin-gonic/gin"
 )
 
 type ProfileUpdateRequest struct {
-	FechaNacimiento string `json:"fecha_nacimiento"`
-	AnoIngreso      string `json:"ano_ingreso"`
+	FechaNacimiento string `json:"fecha_nacimiento" example:"2001-03-15"` // AAAA-MM-DD
+	AnoIngreso      string `json:"ano_ingreso" example:"2020"`
 	IdCarrera       uint   `json:"id_carrera"`
 }
 
@@ -23,37 +28,65 @@ type ProfileUpdateRequest struct {
 // @Param Authorization header string true "Bearer token"
 // @Param profile body ProfileUpdateRequest true "Datos para actualizar el perfil"
 // @Success 200 {object} SuccessResponse "Perfil actualizado correctamente"
-// @Failure 400 {object} ErrorResponse "Datos inválidos"
-// @Failure 401 {object} ErrorResponse "Usuario no autenticado"
-// @Failure 500 {object} ErrorResponse "Error al actualizar el perfil"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos con detalle por campo (fecha fuera del rango de edad, año de ingreso futuro o anterior a la carrera) o carrera inexistente o inactiva (carrera_invalid)"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Failure 500 {object} apierror.ErrorResponse "Error al actualizar el perfil"
 // @Router /complete-profile [post]
-func CompleteProfileHandler(c *gin.Context) {
+func (s *Service) CompleteProfileHandler(c *gin.Context) {
 	uid, exists := c.Get("uid")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		apierror.Respond(c, apierror.Unauthenticated)
 		return
 	}
 
 	// Obtener los datos del perfil desde el cuerpo de la solicitud
 	var req ProfileUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos"})
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	// Validar que la carrera exista y esté activa
+	var idCarrera *uint
+	if req.IdCarrera != 0 {
+		if err := academico.ValidateCarrera(s.db.WithContext(c.Request.Context()), req.IdCarrera, "id_carrera"); err != nil {
+			apierror.Respond(c, err)
+			return
+		}
+		idCarrera = &req.IdCarrera
+	}
+
+	// El año de ingreso se valida contra la carrera enviada o, si no viene, contra la actual
+	usuario, err := s.usuarios.FindByFirebaseUID(c.Request.Context(), uid.(string))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Respond(c, apierror.AccountNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	carreraIngreso := req.IdCarrera
+	if carreraIngreso == 0 && usuario.Id_carrera != nil {
+		carreraIngreso = *usuario.Id_carrera
+	}
+	datos, err := perfil.Validar(s.db.WithContext(c.Request.Context()), req.FechaNacimiento, req.AnoIngreso, carreraIngreso)
+	if err != nil {
+		apierror.Respond(c, err)
 		return
 	}
 
 	// Actualizar solo los campos no relacionados con la foto de perfil
-	var usuario models.Usuario
-	result := database.DB.Model(&usuario).Where("firebase_usuario = ?", uid).Updates(models.Usuario{
-		Fecha_nacimiento: req.FechaNacimiento,
-		Ano_ingreso:      req.AnoIngreso,
-		Id_carrera:       req.IdCarrera,
-		PerfilCompletado: true,
+	err = s.usuarios.UpdatePerfil(c.Request.Context(), uid.(string), repository.PerfilUsuario{
+		Fecha_nacimiento: datos.Fecha_nacimiento,
+		Ano_ingreso:      datos.Ano_ingreso,
+		Id_carrera:       idCarrera,
 	})
-
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar el perfil"})
+	if err != nil {
+		apierror.Respond(c, apierror.ProfileUpdateFailed.Wrap(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Perfil actualizado correctamente"})
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.profile_updated")})
 }
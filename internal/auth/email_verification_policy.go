@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"login/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Modos de la política de verificación de correo
+const (
+	verificacionEstricta = "strict" // no se permite iniciar sesión sin verificar el correo
+	verificacionGracia   = "grace"  // se permite durante el periodo de gracia desde el registro
+	verificacionApagada  = "off"    // no se exige verificación
+)
+
+const defaultGrace = 7 * 24 * time.Hour
+
+// errEmailNotVerified incluye una indicación para reenviar el correo de verificación
+var errEmailNotVerified = apierror.EmailNotVerified.WithDetail("hint", apierror.Localized("hint.resend_verification"))
+
+// verificationPolicy decide si una cuenta sin verificar puede iniciar sesión
+type verificationPolicy struct {
+	mode  string
+	grace time.Duration
+}
+
+// newVerificationPolicy construye la política. Sin modo se usa grace con 7 días de gracia
+func newVerificationPolicy(mode string, grace time.Duration) verificationPolicy {
+	switch mode = strings.ToLower(mode); mode {
+	case verificacionEstricta, verificacionGracia, verificacionApagada:
+		return verificationPolicy{mode: mode, grace: grace}
+	default:
+		return verificationPolicy{mode: verificacionGracia, grace: defaultGrace}
+	}
+}
+
+// allows indica si la cuenta puede continuar y, durante el periodo de gracia, hasta cuándo
+func (p verificationPolicy) allows(verified bool, createdAt time.Time) (bool, *time.Time) {
+	if verified || p.mode == verificacionApagada {
+		return true, nil
+	}
+	if p.mode == verificacionEstricta {
+		return false, nil
+	}
+	deadline := createdAt.Add(p.grace)
+	return time.Now().Before(deadline), &deadline
+}
+
+// emailVerificationStatus consulta en Firebase si el correo está verificado y cuándo se creó la cuenta.
+// dbVerified permite considerar el estado guardado en la base de datos (Id_estado_usuario)
+func (s *Service) emailVerificationStatus(ctx context.Context, uid string, dbVerified bool) (bool, *time.Time, error) {
+	policy := s.verification
+	if dbVerified || policy.mode == verificacionApagada {
+		return true, nil, nil
+	}
+
+	userRecord, err := s.identity.GetUser(ctx, uid)
+	if err != nil {
+		return false, nil, apierror.SignInUnavailable.Wrap(err)
+	}
+
+	allowed, deadline := policy.allows(userRecord.EmailVerified, userRecord.CreatedAt)
+	if !allowed {
+		return false, deadline, errEmailNotVerified
+	}
+	return userRecord.EmailVerified, deadline, nil
+}
+
+// RequireVerifiedEmail exige un correo verificado según la política configurada.
+// Debe usarse después de AuthMiddleware
+func (s *Service) RequireVerifiedEmail(c *gin.Context) {
+	if verified, _ := c.Get("email_verified"); verified == true {
+		c.Next()
+		return
+	}
+
+	uid := c.GetString("uid")
+	if _, _, err := s.emailVerificationStatus(c.Request.Context(), uid, false); err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.Next()
+}
@@ -1,52 +1,188 @@
 package auth
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/logging"
 
 	firebase "firebase.google.com/go/v4"
-	"firebase.google.com/go/v4/auth" // Este es el paquete correcto para autenticación
+	"firebase.google.com/go/v4/auth"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/api/option"
 )
 
-var authClient *auth.Client // Cambiado de firebase.Auth a auth.Client
+// FirebaseIdentity implementa IdentityProvider con el Admin SDK de Firebase y la API REST de
+// Identity Toolkit para el inicio de sesión con contraseña
+type FirebaseIdentity struct {
+	client    *auth.Client
+	apiKey    string
+	http      *http.Client
+	signInURL string
+}
+
+// signInWithPasswordURL es el endpoint de Identity Toolkit para iniciar sesión con contraseña.
+// La API key va en el encabezado X-Goog-Api-Key y no en la URL, que queda en los spans de otelhttp
+const signInWithPasswordURL = "https://identitytoolkit.googleapis.com/v1/accounts:signInWithPassword"
 
-// InitFirebase inicializa Firebase con el archivo de credenciales
-func InitFirebase() error {
-	opt := option.WithCredentialsFile("config/serviceAccountKey.json") // Ruta a tus credenciales
-	app, err := firebase.NewApp(context.Background(), nil, opt)
+// NewFirebaseIdentity inicializa Firebase con el archivo de credenciales y la API key web
+func NewFirebaseIdentity(ctx context.Context, credentialsFile, apiKey string) (*FirebaseIdentity, error) {
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(credentialsFile))
 	if err != nil {
-		return fmt.Errorf("error inicializando Firebase: %v", err)
+		return nil, fmt.Errorf("error inicializando Firebase: %v", err)
 	}
 
 	// Inicializar el cliente de autenticación
-	authClient, err = app.Auth(context.Background()) // Utilizar el método correcto para obtener el cliente de auth
+	client, err := app.Auth(ctx)
 	if err != nil {
-		return fmt.Errorf("error creando el cliente de autenticación: %v", err)
+		return nil, fmt.Errorf("error creando el cliente de autenticación: %v", err)
+	}
+
+	slog.Info("Firebase inicializado correctamente")
+	return &FirebaseIdentity{client: client, apiKey: apiKey, signInURL: signInWithPasswordURL, http: &http.Client{
+		Timeout: 10 * time.Second,
+		// Traza las llamadas a Identity Toolkit y propaga el contexto de traza
+		Transport: otelhttp.NewTransport(logging.Transport(http.DefaultTransport)),
+	}}, nil
+}
+
+// firebaseError traduce los errores del Admin SDK a los errores del paquete
+func firebaseError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case auth.IsUserNotFound(err):
+		return ErrIdentityNotFound
+	case auth.IsEmailAlreadyExists(err):
+		return ErrIdentityEmailExists
+	default:
+		return err
+	}
+}
+
+func identityFromRecord(record *auth.UserRecord) *Identity {
+	identity := &Identity{
+		UID:           record.UID,
+		Email:         record.Email,
+		EmailVerified: record.EmailVerified,
+		Disabled:      record.Disabled,
+	}
+	if record.UserMetadata != nil {
+		identity.CreatedAt = time.UnixMilli(record.UserMetadata.CreationTimestamp)
 	}
+	return identity
+}
 
-	log.Println("Firebase inicializado correctamente")
-	return nil
+func (f *FirebaseIdentity) VerifyIDToken(ctx context.Context, idToken string) (*IdentityToken, error) {
+	token, err := f.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityToken{UID: token.UID, EmailVerified: token.Claims["email_verified"] == true}, nil
 }
 
-// VerifyHandler maneja las solicitudes para verificar el token de autenticación
-func VerifyHandler(w http.ResponseWriter, r *http.Request) {
-	// Obtener el token del encabezado Authorization
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		http.Error(w, "Falta el token de autorización", http.StatusUnauthorized)
-		return
+func (f *FirebaseIdentity) SignIn(ctx context.Context, email, password string) (string, error) {
+	loginPayload := map[string]interface{}{
+		"email":             email,
+		"password":          password,
+		"returnSecureToken": true,
+	}
+	jsonPayload, _ := json.Marshal(loginPayload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.signInURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return "", apierror.SignInUnavailable.Wrap(err)
 	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Goog-Api-Key", f.apiKey)
 
-	// Verificar el token con Firebase
-	token, err := authClient.VerifyIDToken(context.Background(), authHeader)
+	resp, err := f.http.Do(req)
 	if err != nil {
-		http.Error(w, "Token inválido", http.StatusUnauthorized)
-		return
+		return "", apierror.SignInUnavailable.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var firebaseErr identityToolkitError
+		if err := json.NewDecoder(resp.Body).Decode(&firebaseErr); err != nil {
+			return "", apierror.SignInUnavailable
+		}
+		signInErr := mapIdentityToolkitError(firebaseErr.Error.Message)
+		if signInErr == apierror.SignInUnavailable {
+			logging.FromContext(ctx).Error("Inicio de sesión: Identity Toolkit no disponible", "status", resp.StatusCode, "error", firebaseErr.Error.Message)
+		}
+		return "", signInErr
+	}
+
+	var firebaseResp FirebaseLoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&firebaseResp); err != nil || firebaseResp.IDToken == "" {
+		return "", apierror.SignInUnavailable
 	}
 
-	// Si el token es válido, responde con el UID del usuario
-	fmt.Fprintf(w, "Token válido! UID: %s", token.UID)
+	return firebaseResp.IDToken, nil
+}
+
+func (f *FirebaseIdentity) CreateUser(ctx context.Context, email, password string) (*Identity, error) {
+	record, err := f.client.CreateUser(ctx, (&auth.UserToCreate{}).Email(email).Password(password))
+	if err != nil {
+		return nil, firebaseError(err)
+	}
+	return identityFromRecord(record), nil
+}
+
+func (f *FirebaseIdentity) GetUser(ctx context.Context, uid string) (*Identity, error) {
+	record, err := f.client.GetUser(ctx, uid)
+	if err != nil {
+		return nil, firebaseError(err)
+	}
+	return identityFromRecord(record), nil
+}
+
+func (f *FirebaseIdentity) GetUserByEmail(ctx context.Context, email string) (*Identity, error) {
+	record, err := f.client.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, firebaseError(err)
+	}
+	return identityFromRecord(record), nil
+}
+
+func (f *FirebaseIdentity) DeleteUser(ctx context.Context, uid string) error {
+	return firebaseError(f.client.DeleteUser(ctx, uid))
+}
+
+func (f *FirebaseIdentity) SetPassword(ctx context.Context, uid, password string) error {
+	_, err := f.client.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Password(password))
+	return firebaseError(err)
+}
+
+func (f *FirebaseIdentity) SetEmailVerified(ctx context.Context, uid string) error {
+	_, err := f.client.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).EmailVerified(true))
+	return firebaseError(err)
+}
+
+func (f *FirebaseIdentity) SetDisabled(ctx context.Context, uid string, disabled bool) error {
+	_, err := f.client.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Disabled(disabled))
+	return firebaseError(err)
+}
+
+func (f *FirebaseIdentity) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	return firebaseError(f.client.RevokeRefreshTokens(ctx, uid))
+}
+
+// healthProbeUID es un UID que no existe; consultarlo valida las credenciales sin efectos
+const healthProbeUID = "readyz-probe"
+
+// Ping verifica que las credenciales de la cuenta de servicio sean aceptadas por Firebase
+func (f *FirebaseIdentity) Ping(ctx context.Context) error {
+	_, err := f.client.GetUser(ctx, healthProbeUID)
+	if err == nil || auth.IsUserNotFound(err) {
+		return nil
+	}
+	return err
 }
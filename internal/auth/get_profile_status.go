@@ -1,10 +1,12 @@
 package auth
 
 import (
-	"login/internal/database"
-	"login/internal/models"
+	"errors"
 	"net/http"
 
+	"login/internal/apierror"
+	"login/internal/repository"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,22 +22,25 @@ type ProfileStatusResponse struct {
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Success 200 {object} ProfileStatusResponse "Estado del perfil"
-// @Failure 400 {object} string "Datos inválidos"
-// @Failure 401 {object} string "Usuario no autenticado"
-// @Failure 500 {object} string "Error interno del servidor"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
 // @Router /profile-status [get]
-func GetProfileStatusHandler(c *gin.Context) {
+func (s *Service) GetProfileStatusHandler(c *gin.Context) {
 	uid, exists := c.Get("uid")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		apierror.Respond(c, apierror.Unauthenticated)
 		return
 	}
 
 	// Buscar el usuario por el uid de Firebase
-	var usuario models.Usuario
-	result := database.DB.Where("firebase_usuario = ?", uid).First(&usuario)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al buscar el usuario en la base de datos"})
+	usuario, err := s.usuarios.FindByFirebaseUID(c.Request.Context(), uid.(string))
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Respond(c, apierror.AccountNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
 		return
 	}
 
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrIdentityNotFound indica que el proveedor de identidad no tiene la cuenta
+	ErrIdentityNotFound = errors.New("cuenta no encontrada en el proveedor de identidad")
+	// ErrIdentityEmailExists indica que el correo ya está registrado en el proveedor de identidad
+	ErrIdentityEmailExists = errors.New("correo ya registrado en el proveedor de identidad")
+)
+
+// Identity es una cuenta del proveedor de identidad
+type Identity struct {
+	UID           string
+	Email         string
+	EmailVerified bool
+	Disabled      bool
+	CreatedAt     time.Time
+}
+
+// IdentityToken son los datos de un token de sesión verificado
+type IdentityToken struct {
+	UID           string
+	EmailVerified bool
+}
+
+// IdentityProvider son las operaciones sobre las cuentas que el paquete delega en el proveedor
+// de identidad (Firebase Authentication en producción)
+type IdentityProvider interface {
+	// VerifyIDToken valida el token de sesión enviado por el cliente
+	VerifyIDToken(ctx context.Context, idToken string) (*IdentityToken, error)
+	// SignIn autentica con correo y contraseña y devuelve el token de sesión. Los rechazos se
+	// devuelven como *apierror.Error (InvalidCredentials, UserDisabled, TooManyAttempts, ...)
+	SignIn(ctx context.Context, email, password string) (string, error)
+
+	CreateUser(ctx context.Context, email, password string) (*Identity, error)
+	GetUser(ctx context.Context, uid string) (*Identity, error)
+	GetUserByEmail(ctx context.Context, email string) (*Identity, error)
+	DeleteUser(ctx context.Context, uid string) error
+
+	SetPassword(ctx context.Context, uid, password string) error
+	SetEmailVerified(ctx context.Context, uid string) error
+	SetDisabled(ctx context.Context, uid string, disabled bool) error
+	// RevokeRefreshTokens invalida las sesiones abiertas de la cuenta
+	RevokeRefreshTokens(ctx context.Context, uid string) error
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/metrics"
+	"login/internal/tracing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// instrumentedIdentity registra cada llamada al proveedor de identidad en un span y en
+// dependency_call_duration_seconds
+type instrumentedIdentity struct {
+	next IdentityProvider
+}
+
+// Instrument envuelve el proveedor para trazar y medir sus llamadas
+func Instrument(next IdentityProvider) IdentityProvider {
+	return &instrumentedIdentity{next: next}
+}
+
+// instrument abre el span de la operación y devuelve la función que la cierra con su resultado.
+// Las respuestas esperadas del servicio, como un usuario inexistente o credenciales incorrectas,
+// no cuentan como fallas
+func instrument(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "firebase."+operation, semconv.PeerService(metrics.DependencyFirebase))
+	return ctx, func(err error) {
+		var apiErr *apierror.Error
+		if errors.Is(err, ErrIdentityNotFound) || errors.Is(err, ErrIdentityEmailExists) ||
+			(errors.As(err, &apiErr) && !errors.Is(err, apierror.SignInUnavailable)) {
+			err = nil
+		}
+		tracing.End(span, err)
+		metrics.ObserveDependency(metrics.DependencyFirebase, operation, start, err)
+	}
+}
+
+func (m *instrumentedIdentity) VerifyIDToken(ctx context.Context, idToken string) (*IdentityToken, error) {
+	ctx, done := instrument(ctx, "verify_id_token")
+	token, err := m.next.VerifyIDToken(ctx, idToken)
+	done(err)
+	return token, err
+}
+
+func (m *instrumentedIdentity) SignIn(ctx context.Context, email, password string) (string, error) {
+	ctx, done := instrument(ctx, "sign_in")
+	token, err := m.next.SignIn(ctx, email, password)
+	done(err)
+	return token, err
+}
+
+func (m *instrumentedIdentity) CreateUser(ctx context.Context, email, password string) (*Identity, error) {
+	ctx, done := instrument(ctx, "create_user")
+	identity, err := m.next.CreateUser(ctx, email, password)
+	done(err)
+	return identity, err
+}
+
+func (m *instrumentedIdentity) GetUser(ctx context.Context, uid string) (*Identity, error) {
+	ctx, done := instrument(ctx, "get_user")
+	identity, err := m.next.GetUser(ctx, uid)
+	done(err)
+	return identity, err
+}
+
+func (m *instrumentedIdentity) GetUserByEmail(ctx context.Context, email string) (*Identity, error) {
+	ctx, done := instrument(ctx, "get_user_by_email")
+	identity, err := m.next.GetUserByEmail(ctx, email)
+	done(err)
+	return identity, err
+}
+
+func (m *instrumentedIdentity) DeleteUser(ctx context.Context, uid string) error {
+	ctx, done := instrument(ctx, "delete_user")
+	err := m.next.DeleteUser(ctx, uid)
+	done(err)
+	return err
+}
+
+func (m *instrumentedIdentity) SetPassword(ctx context.Context, uid, password string) error {
+	ctx, done := instrument(ctx, "set_password")
+	err := m.next.SetPassword(ctx, uid, password)
+	done(err)
+	return err
+}
+
+func (m *instrumentedIdentity) SetEmailVerified(ctx context.Context, uid string) error {
+	ctx, done := instrument(ctx, "set_email_verified")
+	err := m.next.SetEmailVerified(ctx, uid)
+	done(err)
+	return err
+}
+
+func (m *instrumentedIdentity) SetDisabled(ctx context.Context, uid string, disabled bool) error {
+	ctx, done := instrument(ctx, "set_disabled")
+	err := m.next.SetDisabled(ctx, uid, disabled)
+	done(err)
+	return err
+}
+
+func (m *instrumentedIdentity) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	ctx, done := instrument(ctx, "revoke_refresh_tokens")
+	err := m.next.RevokeRefreshTokens(ctx, uid)
+	done(err)
+	return err
+}
+
+var _ IdentityProvider = (*instrumentedIdentity)(nil)
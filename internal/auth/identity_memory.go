@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"login/internal/apierror"
+)
+
+// MemoryIdentity implementa IdentityProvider en memoria, para pruebas y desarrollo sin Firebase.
+// El token de sesión de una cuenta es "token-" seguido de su UID
+type MemoryIdentity struct {
+	mu        sync.Mutex
+	cuentas   map[string]*memoryAccount
+	siguiente int
+}
+
+type memoryAccount struct {
+	Identity
+	password string
+}
+
+// NewMemoryIdentity crea un proveedor de identidad vacío en memoria
+func NewMemoryIdentity() *MemoryIdentity {
+	return &MemoryIdentity{cuentas: map[string]*memoryAccount{}}
+}
+
+// TokenFor devuelve el token de sesión que VerifyIDToken acepta para la cuenta
+func (m *MemoryIdentity) TokenFor(uid string) string {
+	return "token-" + uid
+}
+
+func (m *MemoryIdentity) account(uid string) (*memoryAccount, error) {
+	cuenta, ok := m.cuentas[uid]
+	if !ok {
+		return nil, ErrIdentityNotFound
+	}
+	return cuenta, nil
+}
+
+func (m *MemoryIdentity) byEmail(email string) (*memoryAccount, error) {
+	for _, cuenta := range m.cuentas {
+		if cuenta.Email == email {
+			return cuenta, nil
+		}
+	}
+	return nil, ErrIdentityNotFound
+}
+
+func (m *MemoryIdentity) VerifyIDToken(_ context.Context, idToken string) (*IdentityToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for uid, cuenta := range m.cuentas {
+		if m.TokenFor(uid) == idToken && !cuenta.Disabled {
+			return &IdentityToken{UID: uid, EmailVerified: cuenta.EmailVerified}, nil
+		}
+	}
+	return nil, errors.New("token inválido")
+}
+
+func (m *MemoryIdentity) SignIn(_ context.Context, email, password string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cuenta, err := m.byEmail(email)
+	if err != nil || cuenta.password != password {
+		return "", apierror.InvalidCredentials
+	}
+	if cuenta.Disabled {
+		return "", apierror.UserDisabled
+	}
+	return m.TokenFor(cuenta.UID), nil
+}
+
+func (m *MemoryIdentity) CreateUser(_ context.Context, email, password string) (*Identity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.byEmail(email); err == nil {
+		return nil, ErrIdentityEmailExists
+	}
+	m.siguiente++
+	cuenta := &memoryAccount{
+		Identity: Identity{UID: fmt.Sprintf("uid-%d", m.siguiente), Email: email, CreatedAt: time.Now()},
+		password: password,
+	}
+	m.cuentas[cuenta.UID] = cuenta
+	identity := cuenta.Identity
+	return &identity, nil
+}
+
+func (m *MemoryIdentity) GetUser(_ context.Context, uid string) (*Identity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cuenta, err := m.account(uid)
+	if err != nil {
+		return nil, err
+	}
+	identity := cuenta.Identity
+	return &identity, nil
+}
+
+func (m *MemoryIdentity) GetUserByEmail(_ context.Context, email string) (*Identity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cuenta, err := m.byEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	identity := cuenta.Identity
+	return &identity, nil
+}
+
+func (m *MemoryIdentity) DeleteUser(_ context.Context, uid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := m.account(uid); err != nil {
+		return err
+	}
+	delete(m.cuentas, uid)
+	return nil
+}
+
+// update aplica el cambio a la cuenta con el UID
+func (m *MemoryIdentity) update(uid string, cambio func(*memoryAccount)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cuenta, err := m.account(uid)
+	if err != nil {
+		return err
+	}
+	cambio(cuenta)
+	return nil
+}
+
+func (m *MemoryIdentity) SetPassword(_ context.Context, uid, password string) error {
+	return m.update(uid, func(c *memoryAccount) { c.password = password })
+}
+
+func (m *MemoryIdentity) SetEmailVerified(_ context.Context, uid string) error {
+	return m.update(uid, func(c *memoryAccount) { c.EmailVerified = true })
+}
+
+func (m *MemoryIdentity) SetDisabled(_ context.Context, uid string, disabled bool) error {
+	return m.update(uid, func(c *memoryAccount) { c.Disabled = disabled })
+}
+
+// RevokeRefreshTokens solo comprueba que la cuenta exista: los tokens en memoria no expiran
+func (m *MemoryIdentity) RevokeRefreshTokens(_ context.Context, uid string) error {
+	return m.update(uid, func(*memoryAccount) {})
+}
+
+// Comprobar en compilación que las implementaciones cumplen la interfaz
+var (
+	_ IdentityProvider = (*FirebaseIdentity)(nil)
+	_ IdentityProvider = (*MemoryIdentity)(nil)
+)
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateLanguageRequest estructura para guardar la preferencia de idioma
+type UpdateLanguageRequest struct {
+	Idioma string `json:"idioma" binding:"required" example:"en"`
+}
+
+// preferredLanguage devuelve el idioma guardado por el usuario o la empresa con el correo; vacío si no tiene
+func (s *Service) preferredLanguage(ctx context.Context, email string) string {
+	var idioma string
+	if usuario, err := s.usuarios.FindByCorreo(ctx, email); err == nil {
+		idioma = usuario.Idioma
+	} else if empresa, err := s.empresas.FindByCorreo(ctx, email); err == nil {
+		idioma = empresa.Idioma
+	}
+	return i18n.Normalize(idioma)
+}
+
+// languageForEmail devuelve el idioma de los correos para la cuenta: su preferencia guardada
+// o, si no tiene, el idioma de la solicitud
+func (s *Service) languageForEmail(c *gin.Context, email string) string {
+	if lang := s.preferredLanguage(c.Request.Context(), email); lang != "" {
+		return lang
+	}
+	return i18n.FromContext(c)
+}
+
+// UpdateLanguageHandler guarda la preferencia de idioma del usuario o empresa autenticado
+// @Summary Cambiar idioma preferido
+// @Description Guarda el idioma usado en las respuestas de la API y en los correos. Si no hay preferencia se usa Accept-Language
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param idioma body UpdateLanguageRequest true "Idioma (es, en)"
+// @Success 200 {object} SuccessResponse "Idioma actualizado correctamente"
+// @Failure 400 {object} apierror.ErrorResponse "Idioma no soportado"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Router /language [put]
+func (s *Service) UpdateLanguageHandler(c *gin.Context) {
+	uid, exists := c.Get("uid")
+	if !exists {
+		apierror.Respond(c, apierror.Unauthenticated)
+		return
+	}
+
+	var req UpdateLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	lang := i18n.Normalize(req.Idioma)
+	if lang == "" {
+		apierror.Respond(c, apierror.UnsupportedLanguage.WithDetail("idiomas", i18n.Supported()))
+		return
+	}
+
+	err := s.usuarios.SetIdioma(c.Request.Context(), uid.(string), lang)
+	if errors.Is(err, repository.ErrNotFound) {
+		err = s.empresas.SetIdioma(c.Request.Context(), uid.(string), lang)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Respond(c, apierror.AccountNotFound)
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+
+	i18n.SetLanguage(c, lang)
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(lang, "message.language_updated")})
+}
@@ -1,13 +1,12 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
-	"login/internal/database"
-	"login/internal/models"
+	"errors"
 	"net/http"
-	"os"
 	"strings"
+	"time"
+
+	"login/internal/apierror"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,13 +24,11 @@ type FirebaseLoginResponse struct {
 
 // LoginResponse representa la respuesta del inicio de sesión
 type LoginResponse struct {
-	Token string `json:"token"`
-	UID   string `json:"uid"`
-}
-
-// ErrorResponse representa la estructura de un error
-type ErrorResponse struct {
-	Error string `json:"error"`
+	Token         string `json:"token"`
+	UID           string `json:"uid"`
+	EmailVerified bool   `json:"email_verified"`
+	// VerificationDeadline indica hasta cuándo se permite iniciar sesión sin verificar el correo
+	VerificationDeadline *time.Time `json:"verification_deadline,omitempty"`
 }
 
 // UserLoginHandler maneja el inicio de sesión para usuarios
@@ -42,37 +39,57 @@ type ErrorResponse struct {
 // @Produce json
 // @Param user body LoginRequest true "Datos de inicio de sesión"
 // @Success 200 {object} LoginResponse "Inicio de sesión exitoso"
-// @Failure 400 {object} ErrorResponse "Datos inválidos"
-// @Failure 401 {object} ErrorResponse "Credenciales incorrectas"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos (invalid_request, invalid_email, missing_password)"
+// @Failure 401 {object} apierror.ErrorResponse "Credenciales incorrectas (invalid_credentials, user_not_found, company_not_found)"
+// @Failure 403 {object} apierror.ErrorResponse "Cuenta deshabilitada (user_disabled), suspendida (account_suspended) o correo sin verificar (email_not_verified)"
+// @Failure 429 {object} apierror.ErrorResponse "Demasiados intentos (too_many_attempts)"
+// @Failure 502 {object} apierror.ErrorResponse "Servicio de autenticación no disponible (signin_unavailable)"
 // @Router /login/user [post]
-func UserLoginHandler(c *gin.Context) {
+func (s *Service) UserLoginHandler(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos"})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
 	// Autenticar con Firebase
-	token, err := SignInWithEmailAndPassword(email, req.Password)
+	token, err := s.identity.SignIn(c.Request.Context(), email, req.Password)
+	if errors.Is(err, apierror.UserDisabled) {
+		err = s.disabledAccountError(c.Request.Context(), email)
+	}
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Credenciales incorrectas"})
+		apierror.Respond(c, err)
 		return
 	}
 
 	// Buscar al usuario en la tabla Usuario
-	var usuario models.Usuario
-	result := database.DB.Where("correo = ?", email).First(&usuario)
-	if result.Error != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no encontrado"})
+	usuario, err := s.usuarios.FindByCorreo(c.Request.Context(), email)
+	if err != nil {
+		apierror.Respond(c, apierror.UserNotFound)
+		return
+	}
+
+	// La suspensión en la base de datos bloquea el acceso aunque Firebase no esté sincronizado
+	if usuario.Suspendido {
+		apierror.Respond(c, s.suspensionError(usuario.Firebase_usuario))
+		return
+	}
+
+	// Verificar que el correo esté verificado según la política configurada
+	verified, deadline, err := s.emailVerificationStatus(c.Request.Context(), usuario.Firebase_usuario, usuario.Id_estado_usuario)
+	if err != nil {
+		apierror.Respond(c, err)
 		return
 	}
 
 	// Responder con el token JWT y el UID del usuario
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"uid":   usuario.Firebase_usuario,
+	c.JSON(http.StatusOK, LoginResponse{
+		Token:                token,
+		UID:                  usuario.Firebase_usuario,
+		EmailVerified:        verified,
+		VerificationDeadline: deadline,
 	})
 }
 
@@ -84,62 +101,56 @@ func UserLoginHandler(c *gin.Context) {
 // @Produce json
 // @Param company body LoginRequest true "Datos de inicio de sesión"
 // @Success 200 {object} LoginResponse "Inicio de sesión exitoso"
-// @Failure 400 {object} ErrorResponse "Datos inválidos"
-// @Failure 401 {object} ErrorResponse "Credenciales incorrectas"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos (invalid_request, invalid_email, missing_password)"
+// @Failure 401 {object} apierror.ErrorResponse "Credenciales incorrectas (invalid_credentials, user_not_found, company_not_found)"
+// @Failure 403 {object} apierror.ErrorResponse "Cuenta deshabilitada (user_disabled), suspendida (account_suspended) o correo sin verificar (email_not_verified)"
+// @Failure 429 {object} apierror.ErrorResponse "Demasiados intentos (too_many_attempts)"
+// @Failure 502 {object} apierror.ErrorResponse "Servicio de autenticación no disponible (signin_unavailable)"
 // @Router /login/company [post]
-func CompanyLoginHandler(c *gin.Context) {
+func (s *Service) CompanyLoginHandler(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos"})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
 	// Autenticar con Firebase
-	token, err := SignInWithEmailAndPassword(email, req.Password)
+	token, err := s.identity.SignIn(c.Request.Context(), email, req.Password)
+	if errors.Is(err, apierror.UserDisabled) {
+		err = s.disabledAccountError(c.Request.Context(), email)
+	}
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Credenciales incorrectas"})
+		apierror.Respond(c, err)
 		return
 	}
 
 	// Buscar a la empresa en la tabla Usuario_empresa
-	var usuarioEmpresa models.Usuario_empresa
-	result := database.DB.Where("correo_empresa = ?", email).First(&usuarioEmpresa)
-	if result.Error != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Empresa no encontrada"})
+	usuarioEmpresa, err := s.empresas.FindByCorreo(c.Request.Context(), email)
+	if err != nil {
+		apierror.Respond(c, apierror.CompanyNotFound)
 		return
 	}
 
-	// Responder con el token JWT y el UID de la empresa
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"uid":   usuarioEmpresa.Firebase_usuario_empresa,
-	})
-}
-
-// SignInWithEmailAndPassword autentica al usuario con Firebase
-func SignInWithEmailAndPassword(email, password string) (string, error) {
-	apiKey := os.Getenv("FIREBASE_API_KEY")
-	url := "https://identitytoolkit.googleapis.com/v1/accounts:signInWithPassword?key=" + apiKey
-
-	loginPayload := map[string]string{
-		"email":             email,
-		"password":          password,
-		"returnSecureToken": "true",
+	// La suspensión en la base de datos bloquea el acceso aunque Firebase no esté sincronizado
+	if usuarioEmpresa.Suspendido {
+		apierror.Respond(c, s.suspensionError(usuarioEmpresa.Firebase_usuario_empresa))
+		return
 	}
-	jsonPayload, _ := json.Marshal(loginPayload)
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	// Verificar que el correo esté verificado según la política configurada
+	verified, deadline, err := s.emailVerificationStatus(c.Request.Context(), usuarioEmpresa.Firebase_usuario_empresa, false)
 	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	var firebaseResp FirebaseLoginResponse
-	if err := json.NewDecoder(resp.Body).Decode(&firebaseResp); err != nil {
-		return "", err
+		apierror.Respond(c, err)
+		return
 	}
 
-	return firebaseResp.IDToken, nil
+	// Responder con el token JWT y el UID de la empresa
+	c.JSON(http.StatusOK, LoginResponse{
+		Token:                token,
+		UID:                  usuarioEmpresa.Firebase_usuario_empresa,
+		EmailVerified:        verified,
+		VerificationDeadline: deadline,
+	})
 }
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"login/internal/apierror"
+	"login/internal/password"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkPasswordPolicy valida la contraseña con la política configurada y, si no la cumple,
+// responde con el código password_policy y el detalle de cada regla en fields.
+// Devuelve false si ya se respondió
+func (s *Service) checkPasswordPolicy(c *gin.Context, pwd string, info password.UserInfo) bool {
+	policy := s.passwordPolicy
+	violations := policy.Validate(pwd, info)
+	if len(violations) == 0 {
+		return true
+	}
+
+	fields := make([]apierror.FieldError, 0, len(violations))
+	for _, v := range violations {
+		fields = append(fields, apierror.Field("password", v.Rule, "password."+v.Rule, "min", policy.MinLength, "max", policy.MaxLength))
+	}
+	apierror.Respond(c, apierror.PasswordPolicy.WithFields(fields...))
+	return false
+}
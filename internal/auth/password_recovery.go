@@ -1,12 +1,30 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"net/http"
-	"os"
+	"strings"
+	"time"
 
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/logging"
+	"login/internal/mailer"
+	"login/internal/models"
+	"login/internal/outbox"
+
+	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	resetDuracion  = time.Hour
+	resetProposito = "password_reset"
 )
 
 // Estructura de solicitud para el correo de recuperación
@@ -14,50 +32,223 @@ type PasswordResetRequest struct {
 	Email string `json:"email" binding:"required"`
 }
 
+// PasswordResetConfirmRequest estructura para confirmar el restablecimiento con el token recibido
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+var errResetInvalido = errors.New("token de restablecimiento inválido o expirado")
+
 // SendPasswordResetEmailHandler maneja el envío del correo de recuperación
 // @Summary Envía un correo de recuperación de contraseña
-// @Description Permite a los usuarios recuperar su contraseña mediante un correo de recuperación
+// @Description Envía un enlace de un solo uso para restablecer la contraseña. La respuesta es la misma exista o no la cuenta
 // @Tags password
 // @Accept json
 // @Produce json
 // @Param email body PasswordResetRequest true "Correo del usuario"
-// @Success 200 {object} SuccessResponse "Correo de recuperación enviado con éxito"
-// @Failure 400 {object} ErrorResponse "Email requerido"
-// @Failure 500 {object} ErrorResponse "Error al enviar el correo de recuperación"
+// @Success 200 {object} SuccessResponse "Si el correo está registrado, se enviará un enlace de recuperación"
+// @Failure 400 {object} apierror.ErrorResponse "Email requerido"
+// @Failure 500 {object} apierror.ErrorResponse "Error al enviar el correo de recuperación"
 // @Router /password-reset [post]
-// Handler para enviar correo de recuperación de contraseña usando la API REST de Firebase
-func SendPasswordResetEmailHandler(c *gin.Context) {
+func (s *Service) SendPasswordResetEmailHandler(c *gin.Context) {
 	var req PasswordResetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Email requerido"})
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+	respuesta := SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.password_reset_requested")}
+
+	// No se revela si la cuenta existe
+	if _, err := s.identity.GetUserByEmail(c.Request.Context(), email); err != nil {
+		if !errors.Is(err, ErrIdentityNotFound) {
+			logging.From(c).Error("Recuperación de contraseña: error consultando Firebase", "error", err)
+		}
+		c.JSON(http.StatusOK, respuesta)
+		return
+	}
+
+	brand := mailer.BrandFor(appFromRequest(c))
+	lang := s.languageForEmail(c, email)
+	err := s.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		token, err := s.generatePasswordResetToken(tx, email)
+		if err != nil {
+			return err
+		}
+
+		msg, err := mailer.Render(mailer.TemplatePasswordReset, lang, brand, mailer.Data{
+			"Link":          brand.URL + "/reset-password?token=" + token,
+			"ExpiraMinutos": int(resetDuracion.Minutes()),
+		})
+		if err != nil {
+			return err
+		}
+		msg.To = []string{email}
+
+		_, err = outbox.Enqueue(tx, mailer.TemplatePasswordReset, msg)
+		return err
+	})
+	if err != nil {
+		apierror.Respond(c, apierror.ResetEmailFailed.Wrap(err))
 		return
 	}
+	s.outbox.Wake()
 
-	// Construir la solicitud para la API REST de Firebase
-	apiKey := os.Getenv("FIREBASE_API_KEY")
-	url := "https://identitytoolkit.googleapis.com/v1/accounts:sendOobCode?key=" + apiKey
+	c.JSON(http.StatusOK, respuesta)
+}
 
-	// Payload para la solicitud
-	resetPayload := map[string]string{
-		"requestType": "PASSWORD_RESET",
-		"email":       req.Email,
+// ConfirmPasswordResetHandler establece la nueva contraseña usando el token recibido por correo
+// @Summary Confirmar restablecimiento de contraseña
+// @Description Valida el token de un solo uso, establece la nueva contraseña, cierra las sesiones abiertas y envía un correo de confirmación
+// @Tags password
+// @Accept json
+// @Produce json
+// @Param reset body PasswordResetConfirmRequest true "Token y nueva contraseña"
+// @Success 200 {object} SuccessResponse "Contraseña restablecida correctamente"
+// @Failure 400 {object} apierror.ErrorResponse "Token inválido o contraseña que no cumple la política"
+// @Failure 500 {object} apierror.ErrorResponse "Error al restablecer la contraseña"
+// @Router /password-reset/confirm [post]
+func (s *Service) ConfirmPasswordResetHandler(c *gin.Context) {
+	var req PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
 	}
 
-	jsonPayload, _ := json.Marshal(resetPayload)
+	email, jti, err := s.parsePasswordResetToken(req.Token)
+	if err != nil {
+		apierror.Respond(c, apierror.ResetTokenInvalid)
+		return
+	}
 
-	// Enviar la solicitud HTTP POST a la API de Firebase
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	userRecord, err := s.identity.GetUserByEmail(c.Request.Context(), email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al enviar el correo de recuperación"})
+		apierror.Respond(c, apierror.ResetTokenInvalid)
+		return
+	}
+
+	if !s.checkPasswordPolicy(c, req.NewPassword, s.userInfoForUID(c.Request.Context(), userRecord.UID, email)) {
+		return
+	}
+
+	// Consumir el token antes de cambiar la contraseña para que no pueda usarse dos veces
+	if err := s.consumePasswordResetToken(c.Request.Context(), email, jti); err != nil {
+		apierror.Respond(c, apierror.ResetTokenInvalid)
 		return
 	}
-	defer resp.Body.Close()
 
-	// Verificar la respuesta
-	if resp.StatusCode != http.StatusOK {
-		c.JSON(resp.StatusCode, gin.H{"error": "Error desde Firebase al enviar el correo de recuperación"})
+	ctx := c.Request.Context()
+	if err := s.identity.SetPassword(ctx, userRecord.UID, req.NewPassword); err != nil {
+		apierror.Respond(c, apierror.PasswordUpdateFailed.Wrap(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Correo de recuperación enviado con éxito"})
+	// Cerrar todas las sesiones abiertas con la contraseña anterior
+	if err := s.identity.RevokeRefreshTokens(ctx, userRecord.UID); err != nil {
+		logging.From(c).Error("Recuperación de contraseña: error revocando sesiones", "uid", userRecord.UID, "error", err)
+	}
+
+	if err := sendSecurityAlert(s.db.WithContext(c.Request.Context()), email, appFromRequest(c), s.languageForEmail(c, email), "event.password_reset", c.ClientIP()); err != nil {
+		logging.From(c).Error("Recuperación de contraseña: error encolando la confirmación", "error", err)
+	} else {
+		s.outbox.Wake()
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.password_reset")})
+}
+
+// generatePasswordResetToken firma un token con identificador único y guarda su hash
+func (s *Service) generatePasswordResetToken(tx *gorm.DB, email string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	jti := hex.EncodeToString(b)
+
+	claims := jwt.MapClaims{
+		"email":   email,
+		"jti":     jti,
+		"purpose": resetProposito,
+		"exp":     time.Now().Add(resetDuracion).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	registro := models.Reset_contrasena{
+		Correo:     email,
+		Token_hash: hashResetJTI(jti),
+		Expira_en:  time.Now().Add(resetDuracion),
+	}
+	if err := tx.Create(&registro).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// parsePasswordResetToken valida la firma, la expiración y el propósito del token
+func (s *Service) parsePasswordResetToken(tokenString string) (email, jti string, err error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errResetInvalido
+		}
+		return s.secretKey, nil
+	})
+	if err != nil || !token.Valid {
+		return "", "", errResetInvalido
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != resetProposito {
+		return "", "", errResetInvalido
+	}
+	email, _ = claims["email"].(string)
+	jti, _ = claims["jti"].(string)
+	if email == "" || jti == "" {
+		return "", "", errResetInvalido
+	}
+	return email, jti, nil
+}
+
+// consumePasswordResetToken marca el token como usado e invalida los demás tokens pendientes del correo
+func (s *Service) consumePasswordResetToken(ctx context.Context, email, jti string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Reset_contrasena{}).
+			Where("token_hash = ? AND correo = ? AND usado_en IS NULL AND expira_en > ?", hashResetJTI(jti), email, now).
+			Update("usado_en", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errResetInvalido
+		}
+		return tx.Model(&models.Reset_contrasena{}).
+			Where("correo = ? AND usado_en IS NULL", email).
+			Update("usado_en", now).Error
+	})
+}
+
+func hashResetJTI(jti string) string {
+	sum := sha256.Sum256([]byte(jti))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendSecurityAlert encola un correo de alerta de seguridad; evento es la clave de i18n del evento
+func sendSecurityAlert(tx *gorm.DB, email, app, lang, evento, ip string) error {
+	msg, err := mailer.Render(mailer.TemplateSecurityAlert, lang, mailer.BrandFor(app), mailer.Data{
+		"Evento": i18n.T(lang, evento),
+		"Fecha":  time.Now().Format("02-01-2006 15:04"),
+		"IP":     ip,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = []string{email}
+
+	_, err = outbox.Enqueue(tx, mailer.TemplateSecurityAlert, msg)
+	return err
 }
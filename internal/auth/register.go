@@ -1,13 +1,17 @@
 package auth
 
 import (
-	"context"
-	"login/internal/database"
-	"login/internal/models"
 	"net/http"
+	"strings"
+
+	"login/internal/academico"
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+	"login/internal/password"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RegisterRequest estructura de los datos recibidos
@@ -33,71 +37,64 @@ type RegisterResponse struct {
 // @Produce json
 // @Param user body RegisterRequest true "Datos del usuario a registrar"
 // @Success 200 {object} RegisterResponse "Usuario registrado correctamente"
-// @Failure 400 {object} RegisterResponse "Solicitud inválida"
-// @Failure 500 {object} RegisterResponse "Error interno del servidor"
+// @Failure 400 {object} apierror.ErrorResponse "Solicitud inválida, carrera inexistente o inactiva, o contraseña que no cumple la política, o correo con una cuenta existente y otra contraseña"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
 // @Router /register/user [post]
 // RegisterHandler maneja el registro del usuario
-func RegisterHandler(c *gin.Context) {
+func (s *Service) RegisterHandler(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	//Verificar si el correo ya está registrado como empresa
-	var empresa models.Usuario_empresa
-	if result := database.DB.Where("correo_empresa = ?", req.Email).First(&empresa); result.RowsAffected > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "El correo ya está registrado como empresa"})
-		return
-	}
-
-	// Verificar si el correo ya está registrado como usuario
-	var usuarioExistente models.Usuario
-	if result := database.DB.Where("correo = ?", req.Email).First(&usuarioExistente); result.RowsAffected > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "El correo ya está registrado como usuario"})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
-	// Crear el usuario en Firebase con email y password
-	params := (&auth.UserToCreate{}).
-		Email(req.Email).
-		Password(req.Password)
+	email := strings.TrimSpace(strings.ToLower(req.Email))
 
-	user, err := authClient.CreateUser(context.Background(), params)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al crear usuario en Firebase: " + err.Error()})
-		return
+	// La carrera es opcional al registrarse, pero si se indica debe existir y estar activa
+	var idCarrera *uint
+	if req.Id_carrera != 0 {
+		if err := academico.ValidateCarrera(s.db.WithContext(c.Request.Context()), req.Id_carrera, "Id_carrera"); err != nil {
+			apierror.Respond(c, err)
+			return
+		}
+		idCarrera = &req.Id_carrera
 	}
 
-	// Crear el usuario en la base de datos sin almacenar la contraseña
-	usuario := models.Usuario{
-		Correo:           req.Email,
-		Nombres:          req.Nombres,
-		Apellidos:        req.Apellidos,
-		Firebase_usuario: user.UID,
-		Id_carrera:       1,
-		Rol:              "estudiante", // Rol por defecto
-	}
-
-	result := database.DB.Create(&usuario)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al guardar el usuario en la base de datos"})
+	// Validar la contraseña con la política de seguridad
+	if !s.checkPasswordPolicy(c, req.Password, password.UserInfo{Email: email, Nombres: req.Nombres, Apellidos: req.Apellidos}) {
 		return
 	}
 
-	// Generar token de verificación de correo
-	token, err := GenerateVerificationToken(req.Email)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al generar el token de verificación"})
-		return
+	// Crear el usuario en Firebase y en la base de datos sin almacenar la contraseña;
+	// si falla la base de datos se elimina el usuario de Firebase
+	saga := &registrationSaga{
+		svc:      s,
+		email:    email,
+		password: req.Password,
+		persist: func(tx *gorm.DB, uid string) error {
+			usuario := models.Usuario{
+				Correo:           email,
+				Nombres:          req.Nombres,
+				Apellidos:        req.Apellidos,
+				Firebase_usuario: uid,
+				Id_carrera:       idCarrera,
+				Rol:              "estudiante", // Rol por defecto
+			}
+			if err := s.usuarios.WithTx(tx).Create(c.Request.Context(), &usuario); err != nil {
+				return err
+			}
+			// Encolar el correo de verificación en la misma transacción
+			return s.SendVerification(tx, email, appFromRequest(c), i18n.FromContext(c))
+		},
 	}
 
-	// Enviar correo de verificación
-	err = SendVerificationEmail(req.Email, token)
+	uid, err := saga.run(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al enviar el correo de verificación"})
+		apierror.Respond(c, err)
 		return
 	}
+	s.outbox.Wake()
 
 	// Respuesta exitosa
-	c.JSON(http.StatusOK, gin.H{"message": "Usuario creado correctamente. Verifica tu correo", "firebase_uid": user.UID})
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.user_registered"), "firebase_uid": uid})
 }
@@ -1,13 +1,16 @@
 package auth
 
 import (
-	"context"
-	"login/internal/database"
-	"login/internal/models"
 	"net/http"
+	"strings"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+	"login/internal/password"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RegisterRequest estructura de los datos recibidos
@@ -31,71 +34,53 @@ type RegisterResponse_empresa struct {
 // @Produce json
 // @Param user body RegisterRequest_empresa true "Datos del usuario a registrar"
 // @Success 200 {object} RegisterResponse_empresa "Usuario registrado correctamente"
-// @Failure 400 {object} RegisterResponse_empresa "Solicitud inválida"
-// @Failure 500 {object} RegisterResponse_empresa "Error interno del servidor"
+// @Failure 400 {object} apierror.ErrorResponse "Solicitud inválida o contraseña que no cumple la política, o correo con una cuenta existente y otra contraseña"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
 // @Router /register_empresa [post]
 // RegisterHandler maneja el registro del usuario
-func RegisterHandler_empresa(c *gin.Context) {
+func (s *Service) RegisterHandler_empresa(c *gin.Context) {
 	var req RegisterRequest_empresa
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
-	// Verificar si el correo ya está registrado como usuario
-	var usuario models.Usuario
-	if result := database.DB.Where("correo = ?", req.Email_empresa).First(&usuario); result.RowsAffected > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "El correo ya está registrado como usuario"})
-		return
-	}
+	email := strings.TrimSpace(strings.ToLower(req.Email_empresa))
 
-	// Verificar si el correo ya está registrado como empresa
-	var empresa models.Usuario_empresa
-	if result := database.DB.Where("correo_empresa = ?", req.Email_empresa).First(&empresa); result.RowsAffected > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "El correo ya está registrado como empresa"})
+	// Validar la contraseña con la política de seguridad
+	if !s.checkPasswordPolicy(c, req.Password, password.UserInfo{Email: email, Nombres: req.Nombre_empresa}) {
 		return
 	}
 
-	// Crear el usuario en Firebase con email y password
-	params := (&auth.UserToCreate{}).
-		Email(req.Email_empresa).
-		Password(req.Password)
-
-	user, err := authClient.CreateUser(context.Background(), params)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al crear usuario en Firebase: " + err.Error()})
-		return
-	}
-
-	// Crear el usuario en la base de datos sin almacenar la contraseña
-	usuario_empresa := models.Usuario_empresa{
-		Correo_empresa:           req.Email_empresa,
-		Nombre_empresa:           req.Nombre_empresa,
-		Perfil_Completado:        false,
-		Firebase_usuario_empresa: user.UID,
-		Rol:                      "empresa", // Rol por defecto
-	}
-
-	result := database.DB.Create(&usuario_empresa)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al guardar el usuario en la base de datos"})
-		return
-	}
-
-	// Generar token de verificación de correo
-	token, err := GenerateVerificationToken(req.Email_empresa)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al generar el token de verificación"})
-		return
+	// Crear el usuario en Firebase y en la base de datos sin almacenar la contraseña;
+	// si falla la base de datos se elimina el usuario de Firebase
+	saga := &registrationSaga{
+		svc:      s,
+		email:    email,
+		password: req.Password,
+		persist: func(tx *gorm.DB, uid string) error {
+			usuario_empresa := models.Usuario_empresa{
+				Correo_empresa:           email,
+				Nombre_empresa:           req.Nombre_empresa,
+				Perfil_Completado:        false,
+				Firebase_usuario_empresa: uid,
+				Rol:                      "empresa", // Rol por defecto
+			}
+			if err := s.empresas.WithTx(tx).Create(c.Request.Context(), &usuario_empresa); err != nil {
+				return err
+			}
+			// Encolar el correo de verificación en la misma transacción
+			return s.SendVerification(tx, email, appFromRequest(c), i18n.FromContext(c))
+		},
 	}
 
-	// Enviar correo de verificación
-	err = SendVerificationEmail(req.Email_empresa, token)
+	uid, err := saga.run(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al enviar el correo de verificación"})
+		apierror.Respond(c, err)
 		return
 	}
+	s.outbox.Wake()
 
 	// Respuesta exitosa
-	c.JSON(http.StatusOK, gin.H{"message": "Usuario empresa creado correctamente. Verifica tu correo", "firebase_uid": user.UID})
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.company_registered"), "firebase_uid": uid})
 }
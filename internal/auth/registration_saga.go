@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/logging"
+
+	"gorm.io/gorm"
+)
+
+const (
+	compensacionIntentos = 3
+	compensacionEspera   = 200 * time.Millisecond
+)
+
+// registrationSaga coordina la creación de la cuenta en Firebase y en la base de datos.
+// Si un paso posterior a la creación en Firebase falla, elimina el usuario de Firebase
+// para que el correo pueda volver a registrarse
+type registrationSaga struct {
+	svc      *Service
+	email    string
+	password string
+
+	// persist guarda las filas locales dentro de la transacción con el UID de Firebase
+	persist func(tx *gorm.DB, uid string) error
+}
+
+// run ejecuta el registro y devuelve el UID de Firebase o un *apierror.Error
+func (s *registrationSaga) run(ctx context.Context) (string, error) {
+	if err := s.svc.checkEmailAvailable(ctx, s.email); err != nil {
+		return "", err
+	}
+
+	uid, created, err := s.ensureFirebaseUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.svc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.persist(tx, uid)
+	})
+	if err == nil {
+		return uid, nil
+	}
+
+	// Otra solicitud concurrente pudo haber completado el registro con el mismo UID
+	if errRegistrado := s.svc.checkEmailAvailable(ctx, s.email); errRegistrado != nil {
+		return "", errRegistrado
+	}
+
+	// Solo se compensa lo que esta solicitud creó; un usuario adoptado de un intento
+	// anterior queda disponible para el siguiente reintento
+	if created {
+		s.compensate(ctx, uid)
+	}
+	return "", apierror.RegistrationFailed.Wrap(err)
+}
+
+// ensureFirebaseUser crea el usuario en Firebase. Si el correo ya existe en Firebase pero no en
+// la base de datos puede ser un registro anterior incompleto o una cuenta de otra aplicación que
+// comparte el proyecto. Solo se adopta si quien registra demuestra ser el dueño iniciando sesión
+// con esa contraseña, lo que mantiene idempotentes los reintentos del cliente; nunca se cambian
+// las credenciales de una cuenta existente
+func (s *registrationSaga) ensureFirebaseUser(ctx context.Context) (uid string, created bool, err error) {
+	user, err := s.svc.identity.CreateUser(ctx, s.email, s.password)
+	if err == nil {
+		return user.UID, true, nil
+	}
+	if !errors.Is(err, ErrIdentityEmailExists) {
+		return "", false, apierror.IdentityProviderError.Wrap(err)
+	}
+
+	if _, err := s.svc.identity.SignIn(ctx, s.email, s.password); err != nil {
+		if errors.Is(err, apierror.TooManyAttempts) || errors.Is(err, apierror.SignInUnavailable) {
+			return "", false, err
+		}
+		return "", false, apierror.EmailAlreadyRegistered
+	}
+
+	existing, err := s.svc.identity.GetUserByEmail(ctx, s.email)
+	if err != nil {
+		return "", false, apierror.IdentityProviderError.Wrap(err)
+	}
+
+	logging.FromContext(ctx).Info("Registro: se reutiliza el usuario de Firebase de un intento anterior incompleto", "uid", existing.UID)
+	return existing.UID, false, nil
+}
+
+// compensate elimina el usuario de Firebase con reintentos. Si no lo logra, el siguiente
+// intento de registro con el mismo correo y contraseña lo reutilizará
+func (s *registrationSaga) compensate(ctx context.Context, uid string) {
+	// La compensación no se cancela junto con la solicitud, pero conserva su logger y su traza
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	espera := compensacionEspera
+	for intento := 1; intento <= compensacionIntentos; intento++ {
+		err := s.svc.identity.DeleteUser(ctx, uid)
+		if err == nil || errors.Is(err, ErrIdentityNotFound) {
+			return
+		}
+		logging.FromContext(ctx).Warn("Registro: falló un intento de eliminar el usuario de Firebase", "uid", uid, "intento", intento, "error", err)
+		time.Sleep(espera)
+		espera *= 2
+	}
+	logging.FromContext(ctx).Error("Registro: el usuario de Firebase quedó huérfano; se reutilizará si se registra con la misma contraseña", "uid", uid)
+}
+
+// checkEmailAvailable verifica que el correo no esté registrado como usuario ni como empresa
+func (s *Service) checkEmailAvailable(ctx context.Context, email string) error {
+	if _, err := s.usuarios.FindByCorreo(ctx, email); err == nil {
+		return apierror.EmailRegisteredAsUser
+	}
+
+	if _, err := s.empresas.FindByCorreo(ctx, email); err == nil {
+		return apierror.EmailRegisteredAsCompany
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"login/internal/apierror"
+)
+
+func TestEnsureFirebaseUserCorreoExistente(t *testing.T) {
+	const (
+		email    = "ana@utalca.cl"
+		original = "Original123!"
+	)
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{name: "otra contraseña no adopta la cuenta", password: "Intrusa456!", wantErr: apierror.EmailAlreadyRegistered},
+		{name: "la misma contraseña reutiliza el registro incompleto", password: original},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			identity := NewMemoryIdentity()
+			existing, err := identity.CreateUser(ctx, email, original)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saga := &registrationSaga{svc: &Service{identity: identity}, email: email, password: tt.password}
+			uid, created, err := saga.ensureFirebaseUser(ctx)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+			if created {
+				t.Error("no debe crear un usuario nuevo si el correo ya existe")
+			}
+			if tt.wantErr == nil && uid != existing.UID {
+				t.Errorf("uid = %q, se esperaba %q", uid, existing.UID)
+			}
+
+			// Las credenciales de la cuenta existente nunca cambian
+			if _, err := identity.SignIn(ctx, email, original); err != nil {
+				t.Errorf("la contraseña original dejó de funcionar: %v", err)
+			}
+			if tt.password != original {
+				if _, err := identity.SignIn(ctx, email, tt.password); err == nil {
+					t.Error("la contraseña de quien registra no debe quedar asignada a la cuenta")
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package auth
 
 import (
 	"net/http"
+	"strings"
 
-	"login/internal/database"
-	"login/internal/models"
+	"login/internal/apierror"
+	"login/internal/i18n"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // EmailRequest estructura para recibir el email
@@ -27,38 +29,33 @@ type SuccessResponse struct {
 // @Produce json
 // @Param email body EmailRequest true "Correo del usuario"
 // @Success 200 {object} SuccessResponse "Correo de verificación enviado nuevamente"
-// @Failure 400 {object} ErrorResponse "Email requerido"
-// @Failure 404 {object} ErrorResponse "Usuario no encontrado"
-// @Failure 500 {object} ErrorResponse "Error interno del servidor"
+// @Failure 400 {object} apierror.ErrorResponse "Email requerido"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
 // @Router /resend-verification [post]
-func ResendVerificationEmailHandler(c *gin.Context) {
+func (s *Service) ResendVerificationEmailHandler(c *gin.Context) {
 	var req EmailRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Email requerido"})
+		apierror.Respond(c, apierror.FromBinding(err))
 		return
 	}
 
-	// Buscar al usuario en la base de datos usando su email
-	var usuario models.Usuario
-	result := database.DB.Where("correo = ?", req.Email).First(&usuario)
-	if result.Error != nil {
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Usuario no encontrado"})
+	// Buscar al usuario o empresa en la base de datos usando su email
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+	if s.checkEmailAvailable(c.Request.Context(), email) == nil {
+		apierror.Respond(c, apierror.AccountNotFound)
 		return
 	}
 
-	// Generar token de verificación
-	token, err := GenerateVerificationToken(req.Email)
+	// Generar y enviar el enlace y/o código de verificación
+	err := s.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		return s.SendVerification(tx, email, appFromRequest(c), s.languageForEmail(c, email))
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Error al generar el token de verificación"})
+		apierror.Respond(c, apierror.VerificationEmailFailed.Wrap(err))
 		return
 	}
+	s.outbox.Wake()
 
-	// Enviar correo de verificación
-	err = SendVerificationEmail(req.Email, token)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Error al enviar el correo de verificación"})
-		return
-	}
-
-	c.JSON(http.StatusOK, SuccessResponse{Message: "Correo de verificación enviado nuevamente"})
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.verification_resent")})
 }
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"time"
+
+	"login/internal/outbox"
+	"login/internal/password"
+	"login/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// Service reúne las dependencias de los handlers de autenticación y cuentas. Los handlers y
+// middlewares del paquete son métodos de Service
+type Service struct {
+	db        *gorm.DB
+	identity  IdentityProvider
+	usuarios  repository.UsuarioRepository
+	empresas  repository.EmpresaRepository
+	outbox    *outbox.Worker // Se despierta después de encolar correos
+	secretKey []byte         // Firma los tokens de verificación y de restablecimiento de contraseña
+	baseURL   string         // URL pública de la API para los enlaces de los correos
+
+	verificationMethod string             // link, code o both
+	verification       verificationPolicy // inicio de sesión de las cuentas sin verificar
+	passwordPolicy     password.Policy
+}
+
+// Options son los ajustes del servicio que vienen de la configuración
+type Options struct {
+	SecretKey []byte
+	BaseURL   string
+
+	// VerificationMethod es cómo se verifica el correo: link, code o both. Por defecto link
+	VerificationMethod string
+	// VerificationPolicy decide si se puede iniciar sesión sin verificar el correo: strict, grace
+	// (durante VerificationGrace desde el registro) u off. Por defecto grace durante 7 días
+	VerificationPolicy string
+	VerificationGrace  time.Duration
+	// PasswordPolicy son los requisitos de las contraseñas; si está vacía se usa password.DefaultPolicy
+	PasswordPolicy password.Policy
+}
+
+// NewService crea el servicio de autenticación con sus dependencias
+func NewService(db *gorm.DB, identity IdentityProvider, usuarios repository.UsuarioRepository,
+	empresas repository.EmpresaRepository, mail *outbox.Worker, opts Options) *Service {
+	if opts.PasswordPolicy == (password.Policy{}) {
+		opts.PasswordPolicy = password.DefaultPolicy
+	}
+	return &Service{db: db, identity: identity, usuarios: usuarios, empresas: empresas, outbox: mail,
+		secretKey: opts.SecretKey, baseURL: opts.BaseURL,
+		verificationMethod: normalizeVerificationMethod(opts.VerificationMethod),
+		verification:       newVerificationPolicy(opts.VerificationPolicy, opts.VerificationGrace),
+		passwordPolicy:     opts.PasswordPolicy}
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"strings"
+
+	"login/internal/apierror"
+)
+
+// identityToolkitError es el cuerpo de error que devuelve la API de Identity Toolkit
+type identityToolkitError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// mapIdentityToolkitError traduce el código de Identity Toolkit (ej. "TOO_MANY_ATTEMPTS_TRY_LATER : ...")
+// al error de la API correspondiente
+func mapIdentityToolkitError(message string) *apierror.Error {
+	code, _, _ := strings.Cut(message, " ")
+	switch code {
+	case "EMAIL_NOT_FOUND", "INVALID_PASSWORD", "INVALID_LOGIN_CREDENTIALS":
+		return apierror.InvalidCredentials
+	case "USER_DISABLED":
+		return apierror.UserDisabled
+	case "TOO_MANY_ATTEMPTS_TRY_LATER":
+		return apierror.TooManyAttempts
+	case "INVALID_EMAIL":
+		return apierror.InvalidEmail
+	case "MISSING_PASSWORD":
+		return apierror.MissingPassword
+	default:
+		return apierror.SignInUnavailable
+	}
+}
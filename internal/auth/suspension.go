@@ -0,0 +1,318 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/logging"
+	"login/internal/models"
+	"login/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// suspensionSistema identifica los levantamientos hechos por el job de suspensiones vencidas
+const suspensionSistema = "sistema"
+
+// SuspendAccountRequest estructura para suspender una cuenta
+type SuspendAccountRequest struct {
+	Motivo string `json:"motivo" binding:"required" example:"Publicaciones fraudulentas"`
+	// Expira_en es opcional; sin fecha la suspensión es indefinida
+	Expira_en *time.Time `json:"expira_en" example:"2026-12-31T23:59:59Z"`
+}
+
+// accountType devuelve si el UID pertenece a un usuario o a una empresa
+func (s *Service) accountType(ctx context.Context, uid string) (string, error) {
+	if _, err := s.usuarios.FindByFirebaseUID(ctx, uid); err == nil {
+		return models.TipoCuentaUsuario, nil
+	}
+	if _, err := s.empresas.FindByFirebaseUID(ctx, uid); err == nil {
+		return models.TipoCuentaEmpresa, nil
+	}
+	return "", apierror.AccountNotFound
+}
+
+// markSuspended actualiza la marca de suspensión en la fila del usuario o la empresa.
+// Una cuenta eliminada de la base de datos no impide registrar el cambio de la suspensión
+func (s *Service) markSuspended(ctx context.Context, tx *gorm.DB, tipo, uid string, suspendido bool) error {
+	var err error
+	if tipo == models.TipoCuentaEmpresa {
+		err = s.empresas.WithTx(tx).SetSuspendido(ctx, uid, suspendido)
+	} else {
+		err = s.usuarios.WithTx(tx).SetSuspendido(ctx, uid, suspendido)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// activeSuspension devuelve la suspensión vigente de la cuenta, si existe
+func (s *Service) activeSuspension(uid string) (*models.Suspension_cuenta, bool) {
+	var suspension models.Suspension_cuenta
+	result := s.db.Where("firebase_uid = ? AND levantada_en IS NULL", uid).
+		Order("created_at desc").Limit(1).Find(&suspension)
+	return &suspension, result.Error == nil && result.RowsAffected > 0
+}
+
+// suspensionError construye el error de cuenta suspendida con el motivo y la fecha de término
+func (s *Service) suspensionError(uid string) error {
+	suspension, ok := s.activeSuspension(uid)
+	if !ok {
+		return apierror.AccountSuspended
+	}
+	err := apierror.AccountSuspended.WithDetail("motivo", suspension.Motivo)
+	if suspension.Expira_en != nil {
+		err = err.WithDetail("hasta", suspension.Expira_en)
+	}
+	return err
+}
+
+// disabledAccountError reemplaza user_disabled de Firebase por account_suspended cuando la
+// cuenta fue suspendida por un administrador, para que el cliente muestre el motivo
+func (s *Service) disabledAccountError(ctx context.Context, email string) error {
+	var uids []string
+	if usuario, err := s.usuarios.FindByCorreo(ctx, email); err == nil {
+		uids = append(uids, usuario.Firebase_usuario)
+	}
+	if empresa, err := s.empresas.FindByCorreo(ctx, email); err == nil {
+		uids = append(uids, empresa.Firebase_usuario_empresa)
+	}
+	for _, uid := range uids {
+		if _, ok := s.activeSuspension(uid); ok {
+			return s.suspensionError(uid)
+		}
+	}
+	return apierror.UserDisabled
+}
+
+// SuspendAccountHandler suspende una cuenta: la deshabilita en Firebase, cierra sus sesiones
+// y la marca como suspendida en la base de datos
+// @Summary Suspender una cuenta
+// @Description Suspende a un usuario o empresa con un motivo y una fecha de término opcional. La cuenta se deshabilita en Firebase y se revocan sus sesiones. Una suspensión activa anterior se reemplaza
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uid path string true "UID de Firebase de la cuenta"
+// @Param suspension body SuspendAccountRequest true "Motivo y fecha de término opcional"
+// @Success 200 {object} models.Suspension_cuenta "Suspensión aplicada"
+// @Failure 400 {object} apierror.ErrorResponse "Datos inválidos o intento de suspender la propia cuenta"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 404 {object} apierror.ErrorResponse "Usuario no encontrado"
+// @Failure 502 {object} apierror.ErrorResponse "Error al actualizar la cuenta en Firebase"
+// @Router /admin/accounts/{uid}/suspend [post]
+func (s *Service) SuspendAccountHandler(c *gin.Context) {
+	adminUID := c.GetString("uid")
+	uid := c.Param("uid")
+	if uid == adminUID {
+		apierror.Respond(c, apierror.CannotSuspendSelf)
+		return
+	}
+
+	var req SuspendAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+	if req.Expira_en != nil && !req.Expira_en.After(time.Now()) {
+		apierror.Respond(c, apierror.InvalidRequest.WithFields(apierror.Field("expira_en", "future", "field.future")))
+		return
+	}
+
+	tipo, err := s.accountType(c.Request.Context(), uid)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	// Una vez confirmada la suspensión, Firebase se actualiza aunque el cliente se desconecte
+	ctx := context.WithoutCancel(c.Request.Context())
+	suspension := models.Suspension_cuenta{
+		Firebase_uid:   uid,
+		Tipo_cuenta:    tipo,
+		Motivo:         req.Motivo,
+		Suspendida_por: adminUID,
+		Expira_en:      req.Expira_en,
+	}
+	var anteriores []uint
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Solo puede haber una suspensión activa por cuenta
+		query := tx.Model(&models.Suspension_cuenta{}).Where("firebase_uid = ? AND levantada_en IS NULL", uid)
+		if err := query.Pluck("id", &anteriores).Error; err != nil {
+			return apierror.DatabaseError.Wrap(err)
+		}
+		if len(anteriores) > 0 {
+			if err := tx.Model(&models.Suspension_cuenta{}).Where("id IN ?", anteriores).
+				Updates(map[string]interface{}{"levantada_en": time.Now(), "levantada_por": adminUID}).Error; err != nil {
+				return apierror.DatabaseError.Wrap(err)
+			}
+		}
+		if err := tx.Create(&suspension).Error; err != nil {
+			return apierror.DatabaseError.Wrap(err)
+		}
+		if err := s.markSuspended(ctx, tx, tipo, uid, true); err != nil {
+			return apierror.DatabaseError.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	// Firebase se actualiza después del commit; si falla se deshace la suspensión local para que
+	// ambos lados no queden distintos
+	if err := s.identity.SetDisabled(ctx, uid, true); err != nil {
+		s.revertSuspensionChange(ctx, uid, func(tx *gorm.DB) error {
+			if err := tx.Delete(&models.Suspension_cuenta{}, suspension.Id).Error; err != nil {
+				return err
+			}
+			if len(anteriores) > 0 {
+				return tx.Model(&models.Suspension_cuenta{}).Where("id IN ?", anteriores).
+					Updates(map[string]interface{}{"levantada_en": nil, "levantada_por": ""}).Error
+			}
+			return s.markSuspended(ctx, tx, tipo, uid, false)
+		})
+		apierror.Respond(c, apierror.IdentityUpdateFailed.Wrap(err))
+		return
+	}
+
+	// Cerrar las sesiones abiertas; los tokens vigentes además son rechazados por AuthMiddleware
+	if err := s.identity.RevokeRefreshTokens(ctx, uid); err != nil {
+		logging.FromContext(ctx).Error("Suspensión: error revocando las sesiones", "uid", uid, "error", err)
+	}
+
+	c.JSON(http.StatusOK, suspension)
+}
+
+// UnsuspendAccountHandler levanta la suspensión activa de una cuenta
+// @Summary Levantar la suspensión de una cuenta
+// @Description Levanta la suspensión activa, habilita la cuenta en Firebase y quita la marca en la base de datos
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uid path string true "UID de Firebase de la cuenta"
+// @Success 200 {object} SuccessResponse "Suspensión levantada"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 404 {object} apierror.ErrorResponse "La cuenta no tiene una suspensión activa"
+// @Failure 502 {object} apierror.ErrorResponse "Error al actualizar la cuenta en Firebase"
+// @Router /admin/accounts/{uid}/unsuspend [post]
+func (s *Service) UnsuspendAccountHandler(c *gin.Context) {
+	if err := s.liftSuspension(c.Request.Context(), c.Param("uid"), c.GetString("uid"), false); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.suspension_lifted")})
+}
+
+// AccountSuspensionsHandler lista el historial de suspensiones de una cuenta
+// @Summary Historial de suspensiones
+// @Description Lista las suspensiones de una cuenta, de la más reciente a la más antigua
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param uid path string true "UID de Firebase de la cuenta"
+// @Success 200 {array} models.Suspension_cuenta "Suspensiones de la cuenta"
+// @Failure 403 {object} apierror.ErrorResponse "Acceso restringido a administradores"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /admin/accounts/{uid}/suspensions [get]
+func (s *Service) AccountSuspensionsHandler(c *gin.Context) {
+	var suspensiones []models.Suspension_cuenta
+	if err := s.db.WithContext(c.Request.Context()).Where("firebase_uid = ?", c.Param("uid")).Order("created_at desc").Find(&suspensiones).Error; err != nil {
+		apierror.Respond(c, apierror.DatabaseError.Wrap(err))
+		return
+	}
+	c.JSON(http.StatusOK, suspensiones)
+}
+
+// liftSuspension levanta la suspensión activa de la cuenta. Con soloVencidas solo levanta
+// suspensiones temporales cuya fecha de término ya pasó. Igual que al suspender, la base de datos
+// se confirma primero y el cambio se deshace si Firebase no habilita la cuenta
+func (s *Service) liftSuspension(ctx context.Context, uid, levantadaPor string, soloVencidas bool) error {
+	ctx = context.WithoutCancel(ctx)
+	var suspension models.Suspension_cuenta
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		query := tx.Model(&models.Suspension_cuenta{}).Where("firebase_uid = ? AND levantada_en IS NULL", uid)
+		if soloVencidas {
+			query = query.Where("expira_en IS NOT NULL AND expira_en <= ?", now)
+		}
+		result := query.Limit(1).Find(&suspension)
+		if result.Error != nil {
+			return apierror.DatabaseError.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apierror.SuspensionNotFound
+		}
+
+		if err := tx.Model(&suspension).Updates(map[string]interface{}{"levantada_en": now, "levantada_por": levantadaPor}).Error; err != nil {
+			return apierror.DatabaseError.Wrap(err)
+		}
+		if err := s.markSuspended(ctx, tx, suspension.Tipo_cuenta, uid, false); err != nil {
+			return apierror.DatabaseError.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.identity.SetDisabled(ctx, uid, false); err != nil && !errors.Is(err, ErrIdentityNotFound) {
+		s.revertSuspensionChange(ctx, uid, func(tx *gorm.DB) error {
+			if err := tx.Model(&suspension).Updates(map[string]interface{}{"levantada_en": nil, "levantada_por": ""}).Error; err != nil {
+				return err
+			}
+			return s.markSuspended(ctx, tx, suspension.Tipo_cuenta, uid, true)
+		})
+		return apierror.IdentityUpdateFailed.Wrap(err)
+	}
+	return nil
+}
+
+// revertSuspensionChange deshace con reintentos un cambio de suspensión ya confirmado cuando
+// Firebase no pudo aplicarlo, igual que la compensación del registro
+func (s *Service) revertSuspensionChange(ctx context.Context, uid string, deshacer func(tx *gorm.DB) error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	espera := compensacionEspera
+	for intento := 1; intento <= compensacionIntentos; intento++ {
+		err := s.db.WithContext(ctx).Transaction(deshacer)
+		if err == nil {
+			return
+		}
+		logging.FromContext(ctx).Warn("Suspensión: falló un intento de revertir el cambio local", "uid", uid, "intento", intento, "error", err)
+		time.Sleep(espera)
+		espera *= 2
+	}
+	logging.FromContext(ctx).Error("Suspensión: la base de datos y Firebase quedaron distintos; revisa la cuenta", "uid", uid)
+}
+
+// LiftExpiredSuspensions levanta todas las suspensiones temporales vencidas
+func (s *Service) LiftExpiredSuspensions(ctx context.Context) error {
+	var uids []string
+	if err := s.db.WithContext(ctx).Model(&models.Suspension_cuenta{}).
+		Where("levantada_en IS NULL AND expira_en IS NOT NULL AND expira_en <= ?", time.Now()).
+		Distinct().Pluck("firebase_uid", &uids).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range uids {
+		err := s.liftSuspension(ctx, uid, suspensionSistema, true)
+		if err != nil && !errors.Is(err, apierror.SuspensionNotFound) {
+			slog.Error("Suspensión: error levantando una suspensión vencida", "uid", uid, "error", err)
+			continue
+		}
+		if err == nil {
+			slog.Info("Suspensión: se levantó una suspensión vencida", "uid", uid)
+		}
+	}
+	return nil
+}
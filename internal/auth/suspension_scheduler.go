@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const suspensionCheckInterval = 5 * time.Minute
+
+// SuspensionScheduler levanta periódicamente las suspensiones temporales vencidas
+type SuspensionScheduler struct {
+	svc *Service
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// NewSuspensionScheduler crea el job sobre el servicio de autenticación
+func NewSuspensionScheduler(svc *Service) *SuspensionScheduler {
+	return &SuspensionScheduler{svc: svc}
+}
+
+// Start inicia la revisión en segundo plano; la primera se hace de inmediato
+func (s *SuspensionScheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		ticker := time.NewTicker(suspensionCheckInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.svc.LiftExpiredSuspensions(ctx); err != nil {
+				slog.Error("Suspensión: error buscando suspensiones vencidas", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop detiene la revisión
+func (s *SuspensionScheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.done.Wait()
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	codigoLongitud           = 6
+	codigoDuracion           = 15 * time.Minute
+	codigoMaxIntentos        = 5
+	codigoVentanaIntentos    = time.Hour
+	metodoVerificacionLink   = "link"
+	metodoVerificacionCodigo = "code"
+	metodoVerificacionAmbos  = "both"
+)
+
+// VerifyCodeRequest estructura para verificar el correo con un código numérico
+type VerifyCodeRequest struct {
+	Email string `json:"email" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+}
+
+// normalizeVerificationMethod devuelve el método de verificación (link, code o both); link si no se indica otro
+func normalizeVerificationMethod(metodo string) string {
+	switch strings.ToLower(metodo) {
+	case metodoVerificacionCodigo:
+		return metodoVerificacionCodigo
+	case metodoVerificacionAmbos:
+		return metodoVerificacionAmbos
+	default:
+		return metodoVerificacionLink
+	}
+}
+
+// hashVerificationCode calcula el HMAC del código ligado al correo para no guardarlo en texto plano
+func (s *Service) hashVerificationCode(email, code string) string {
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write([]byte(strings.ToLower(email) + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateVerificationCode genera un código de 6 dígitos, invalida los anteriores y guarda su hash
+func (s *Service) GenerateVerificationCode(tx *gorm.DB, email string) (string, error) {
+	email = strings.TrimSpace(strings.ToLower(email))
+	max := big.NewInt(1_000_000)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("error generando el código: %v", err)
+	}
+	code := fmt.Sprintf("%0*d", codigoLongitud, n.Int64())
+
+	// Solo el último código emitido es válido. Se invalidan antes de leer los intentos para que
+	// un intento en curso sobre el código anterior espere al bloqueo de la fila y no se pierda
+	if err := tx.Model(&models.Codigo_verificacion{}).
+		Where("correo = ? AND usado = ?", email, false).
+		Update("usado", true).Error; err != nil {
+		return "", err
+	}
+
+	// Los intentos se cuentan por correo: el código nuevo hereda los fallidos del anterior durante
+	// codigoVentanaIntentos, así reenviar el código no reinicia el contador
+	var anterior models.Codigo_verificacion
+	if err := tx.Where("correo = ? AND created_at > ?", email, time.Now().Add(-codigoVentanaIntentos)).
+		Order("created_at desc").Limit(1).Find(&anterior).Error; err != nil {
+		return "", err
+	}
+
+	registro := models.Codigo_verificacion{
+		Correo:      email,
+		Codigo_hash: s.hashVerificationCode(email, code),
+		Intentos:    anterior.Intentos,
+		Expira_en:   time.Now().Add(codigoDuracion),
+	}
+	if err := tx.Create(&registro).Error; err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// SendVerification genera el enlace y/o el código según el método configurado y encola el correo
+// con la marca de la aplicación de origen y en el idioma indicado, usando la transacción recibida
+func (s *Service) SendVerification(tx *gorm.DB, email, app, lang string) error {
+	var token, code string
+	var err error
+
+	metodo := s.verificationMethod
+	if metodo != metodoVerificacionCodigo {
+		token, err = s.GenerateVerificationToken(email)
+		if err != nil {
+			return err
+		}
+	}
+	if metodo != metodoVerificacionLink {
+		code, err = s.GenerateVerificationCode(tx, email)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.SendVerificationEmail(tx, email, token, code, app, lang)
+}
+
+// checkVerificationCode valida el código contra el último emitido. Cada comprobación reserva un
+// intento con un UPDATE condicional antes de comparar, así una ráfaga de solicitudes en paralelo
+// no obtiene más de codigoMaxIntentos comparaciones
+func (s *Service) checkVerificationCode(ctx context.Context, email, code string) error {
+	db := s.db.WithContext(ctx)
+
+	var registro models.Codigo_verificacion
+	result := db.Where("correo = ? AND usado = ?", email, false).
+		Order("created_at desc").First(&registro)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return apierror.VerificationCodeInvalid
+	}
+	if result.Error != nil {
+		return apierror.DatabaseError.Wrap(result.Error)
+	}
+
+	if time.Now().After(registro.Expira_en) {
+		return apierror.VerificationCodeExpired
+	}
+
+	// Reservar el intento; si no se actualiza ninguna fila se agotaron los intentos o el código
+	// fue reemplazado por uno nuevo
+	result = db.Model(&registro).Clauses(clause.Returning{Columns: []clause.Column{{Name: "intentos"}}}).
+		Where("usado = ? AND intentos < ?", false, codigoMaxIntentos).
+		UpdateColumn("intentos", gorm.Expr("intentos + 1"))
+	if result.Error != nil {
+		return apierror.DatabaseError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apierror.VerificationCodeLocked
+	}
+
+	esperado := []byte(registro.Codigo_hash)
+	recibido := []byte(s.hashVerificationCode(email, code))
+	if !hmac.Equal(esperado, recibido) {
+		return apierror.VerificationCodeInvalid.WithDetail("intentos_restantes", codigoMaxIntentos-registro.Intentos)
+	}
+
+	// Solo una de dos solicitudes concurrentes con el código correcto lo consume
+	result = db.Model(&registro).Where("usado = ?", false).UpdateColumn("usado", true)
+	if result.Error != nil {
+		return apierror.DatabaseError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apierror.VerificationCodeInvalid
+	}
+	return nil
+}
+
+// VerifyEmailCodeHandler verifica el correo con el código numérico enviado por email
+// @Summary Verificar correo con código
+// @Description Verifica el correo del usuario usando el código de 6 dígitos enviado por email
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param code body VerifyCodeRequest true "Correo y código de verificación"
+// @Success 200 {object} SuccessResponse "Correo verificado exitosamente"
+// @Failure 400 {object} apierror.ErrorResponse "Código inválido o expirado"
+// @Failure 429 {object} apierror.ErrorResponse "Demasiados intentos"
+// @Failure 500 {object} apierror.ErrorResponse "Error interno del servidor"
+// @Router /verify-email/code [post]
+func (s *Service) VerifyEmailCodeHandler(c *gin.Context) {
+	var req VerifyCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, apierror.FromBinding(err))
+		return
+	}
+
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+	if err := s.checkVerificationCode(c.Request.Context(), email, strings.TrimSpace(req.Code)); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	if err := s.markEmailVerified(c.Request.Context(), email); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: i18n.T(i18n.FromContext(c), "message.email_verified")})
+}
@@ -3,88 +3,94 @@ package auth
 import (
 	"context"
 	"net/http"
-	"net/smtp"
-	"os"
 	"time"
 
-	"login/internal/database"
-	"login/internal/models"
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/mailer"
+	"login/internal/outbox"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-var secretKey = []byte(os.Getenv("JWT_SECRET_KEY"))
-
 // Función para generar el token de verificación
-func GenerateVerificationToken(email string) (string, error) {
+func (s *Service) GenerateVerificationToken(email string) (string, error) {
 	claims := jwt.MapClaims{}
 	claims["authorized"] = true
 	claims["email"] = email
 	claims["exp"] = time.Now().Add(time.Hour * 24).Unix() // El token expira en 24 horas
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	return token.SignedString(s.secretKey)
 }
 
-// Función para enviar el correo de verificación
-func SendVerificationEmail(email, token string) error {
-	from := os.Getenv("SMTP_USER")
-	password := os.Getenv("SMTP_PASSWORD")
-	to := email
-	smtpHost := "smtp.gmail.com"
-	smtpPort := "587"
-	baseURL := "https://api-ulink.tssw.info"
-	auth := smtp.PlainAuth("", from, password, smtpHost)
-	msg := []byte("Subject: Verificación de correo\n\nPor favor verifica tu correo haciendo clic en el siguiente enlace:\n" +
-		baseURL + "/verify-email?token=" + token)
+// Función para encolar el correo de verificación con el enlace y/o el código numérico
+// en la bandeja de salida, dentro de la transacción recibida
+func (s *Service) SendVerificationEmail(tx *gorm.DB, email, token, code, app, lang string) error {
+	data := mailer.Data{"Code": code, "ExpiraMinutos": int(codigoDuracion.Minutes())}
+	if token != "" {
+		data["Link"] = s.baseURL + "/verify-email?token=" + token
+	}
 
-	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{to}, msg)
+	msg, err := mailer.Render(mailer.TemplateVerification, lang, mailer.BrandFor(app), data)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{email}
 
+	_, err = outbox.Enqueue(tx, mailer.TemplateVerification, msg)
 	return err
 }
 
-func VerifyEmailHandler(c *gin.Context) {
+// markEmailVerified activa el perfil en la base de datos y marca el correo como verificado en Firebase
+func (s *Service) markEmailVerified(ctx context.Context, email string) error {
+	// Actualizar el estado de validación en la base de datos
+	if err := s.usuarios.MarkEmailVerified(ctx, email); err != nil {
+		return apierror.EmailVerificationFailed.Wrap(err)
+	}
+
+	// Buscar el usuario en Firebase
+	userRecord, err := s.identity.GetUserByEmail(ctx, email)
+	if err != nil {
+		return apierror.EmailVerificationFailed.Wrap(err)
+	}
+
+	// Actualizar el estado del correo como verificado en Firebase
+	err = s.identity.SetEmailVerified(ctx, userRecord.UID)
+	if err != nil {
+		return apierror.EmailVerificationFailed.Wrap(err)
+	}
+
+	return nil
+}
+
+func (s *Service) VerifyEmailHandler(c *gin.Context) {
 	tokenString := c.Query("token")
 
 	// Parsear el token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
+		return s.secretKey, nil
 	})
 
 	// Manejar el error
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Error al procesar el token"})
+		apierror.Respond(c, apierror.VerificationTokenInvalid)
 		return
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		email := claims["email"].(string)
-
-		// Actualizar el estado de validación en la base de datos
-		var usuario models.Usuario
-		result := database.DB.Model(&usuario).Where("correo = ?", email).Update("Id_estado_usuario", true)
-		if result.Error != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar el estado del usuario"})
-			return
-		}
-
-		// Buscar el usuario en Firebase
-		userRecord, err := authClient.GetUserByEmail(context.Background(), email)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al obtener usuario de Firebase"})
-			return
-		}
-
-		// Actualizar el estado del correo como verificado en Firebase
-		_, err = authClient.UpdateUser(context.Background(), userRecord.UID, (&auth.UserToUpdate{}).EmailVerified(true))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar el estado de verificación en Firebase"})
-			return
-		}
-
-		c.JSON(http.StatusOK, gin.H{"message": "Correo verificado exitosamente. Perfil activado."})
-	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Token inválido o expirado"})
+	claims, ok := token.Claims.(jwt.MapClaims)
+	email, _ := claims["email"].(string)
+	// Los tokens con propósito (ej. restablecimiento de contraseña) no sirven para verificar el correo
+	if _, conProposito := claims["purpose"]; !ok || !token.Valid || email == "" || conProposito {
+		apierror.Respond(c, apierror.VerificationTokenInvalid)
+		return
 	}
+
+	if err := s.markEmailVerified(c.Request.Context(), email); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": i18n.T(i18n.FromContext(c), "message.email_verified")})
 }
@@ -0,0 +1,59 @@
+package buildinfo
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Datos de la compilación, asignados con -ldflags "-X login/internal/buildinfo.Version=..."
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describe la versión en ejecución
+type Info struct {
+	Version   string `json:"version" example:"1.4.0"`
+	Commit    string `json:"commit,omitempty" example:"5b0a391"`
+	BuildTime string `json:"build_time,omitempty" example:"2026-10-19T12:00:00Z"`
+	GoVersion string `json:"go_version" example:"go1.23.1"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// Get devuelve la información de la compilación. Si no se asignó con -ldflags, el commit y la
+// fecha se toman de los datos de control de versiones que Go incrusta en el binario
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	return info
+}
+
+// Handler publica la versión en ejecución
+// @Summary Versión de la API
+// @Description Devuelve la versión, el commit y la fecha de compilación del binario en ejecución
+// @Tags health
+// @Produce json
+// @Success 200 {object} buildinfo.Info "Información de la compilación"
+// @Router /version [get]
+func Handler(c *gin.Context) {
+	c.JSON(http.StatusOK, Get())
+}
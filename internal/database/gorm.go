@@ -2,36 +2,40 @@ package database
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+
+	"login/internal/metrics"
+	"login/internal/tracing"
+	"login/pkg/config"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
-
-// InitDatabase inicializa la conexión con la base de datos
-func InitDatabase() error {
+// Open abre la conexión con la base de datos
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	// Construir la URL de conexión a PostgreSQL
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=require disable_prepared_statements=true",
-		os.Getenv("SUPABASE_HOST"),
-		os.Getenv("SUPABASE_USER"),
-		os.Getenv("SUPABASE_PASSWORD"),
-		os.Getenv("SUPABASE_DB"),
-		os.Getenv("SUPABASE_PORT"))
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s disable_prepared_statements=true",
+		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode)
 
 	// Conectar a la base de datos
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true, Logger: logger{level: gormlogger.Warn}})
 	if err != nil {
-		log.Fatalf("Error al conectar a la base de datos: %v", err)
-		return err
+		return nil, fmt.Errorf("error al conectar a la base de datos: %v", err)
+	}
+
+	// Medir y trazar las consultas
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("error registrando las métricas de GORM: %v", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("error registrando las trazas de GORM: %v", err)
 	}
 
 	// Limpiar declaraciones preparadas previas
 	db.Exec("DEALLOCATE ALL")
 
-	DB = db
-	log.Println("Conexión a la base de datos exitosa con GORM")
-	return nil
+	slog.Info("Conexión a la base de datos exitosa con GORM")
+	return db, nil
 }
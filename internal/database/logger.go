@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"login/internal/logging"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQuery es la duración a partir de la cual una consulta se registra como lenta
+const slowQuery = 200 * time.Millisecond
+
+// logger envía los mensajes de GORM al logger de la solicitud. Las consultas se registran con
+// sus parámetros como placeholders para no escribir correos ni contraseñas en los logs
+type logger struct {
+	level gormlogger.LogLevel
+}
+
+func (l logger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return logger{level: level}
+}
+
+func (l logger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Info {
+		logging.FromContext(ctx).Info(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l logger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Warn {
+		logging.FromContext(ctx).Warn(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l logger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Error {
+		logging.FromContext(ctx).Error(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace registra las consultas fallidas y las lentas; el resto solo en nivel debug
+func (l logger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	level := slog.LevelDebug
+	msg := "Consulta"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		level, msg = slog.LevelError, "Consulta fallida"
+	case elapsed > slowQuery && l.level >= gormlogger.Warn:
+		level, msg = slog.LevelWarn, "Consulta lenta"
+	}
+
+	out := logging.FromContext(ctx)
+	if !out.Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	args := []any{"sql", sql, "rows", rows, "latency_ms", float64(elapsed.Microseconds()) / 1000}
+	if level == slog.LevelError {
+		args = append(args, "error", err)
+	}
+	out.Log(ctx, level, msg, args...)
+}
+
+// ParamsFilter quita los valores de las consultas antes de registrarlas
+func (l logger) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
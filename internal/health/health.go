@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"login/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultTimeout es el tiempo máximo de una verificación sin tiempo propio
+const DefaultTimeout = 3 * time.Second
+
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded" // falla una dependencia opcional; el servicio sigue atendiendo
+	StatusError    = "error"
+)
+
+// Checker verifica que una dependencia esté disponible
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta una función a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error { return f(ctx) }
+
+// Pinger lo implementan los servicios externos que saben verificar su propia conexión
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// check es una dependencia registrada con su tiempo máximo
+type check struct {
+	name     string
+	checker  Checker
+	timeout  time.Duration
+	optional bool // si falla solo degrada el servicio, no lo deja fuera de servicio
+}
+
+// CheckResult es el estado de una dependencia. El detalle de las fallas solo se registra en
+// los logs para no exponer hosts ni mensajes internos en un endpoint público
+type CheckResult struct {
+	Status     string `json:"status" example:"ok"`
+	DurationMs int64  `json:"duration_ms" example:"12"`
+}
+
+// Report es la respuesta de /readyz
+type Report struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Handler publica el estado del proceso y de sus dependencias
+type Handler struct {
+	checks []check
+}
+
+// NewHandler crea un handler sin dependencias registradas
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Add registra una dependencia sin la cual el servicio no puede atender solicitudes; si falla,
+// /readyz responde 503. Con timeout cero se usa DefaultTimeout
+func (h *Handler) Add(name string, checker Checker, timeout time.Duration) {
+	h.add(check{name: name, checker: checker, timeout: timeout})
+}
+
+// AddOptional registra una dependencia de la que solo dependen algunas funciones. Si falla,
+// /readyz la informa como degradada pero sigue respondiendo 200
+func (h *Handler) AddOptional(name string, checker Checker, timeout time.Duration) {
+	h.add(check{name: name, checker: checker, timeout: timeout, optional: true})
+}
+
+func (h *Handler) add(chk check) {
+	if chk.timeout <= 0 {
+		chk.timeout = DefaultTimeout
+	}
+	h.checks = append(h.checks, chk)
+}
+
+// Run ejecuta todas las verificaciones en paralelo, cada una con su tiempo máximo
+func (h *Handler) Run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(h.checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range h.checks {
+		wg.Add(1)
+		go func(chk check) {
+			defer wg.Done()
+			result := run(ctx, chk)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[chk.name] = result
+			switch {
+			case result.Status == StatusError:
+				report.Status = StatusError
+			case result.Status == StatusDegraded && report.Status == StatusOK:
+				report.Status = StatusDegraded
+			}
+		}(chk)
+	}
+	wg.Wait()
+	return report
+}
+
+// run ejecuta una verificación; si no responde a tiempo se informa como error aunque la
+// dependencia ignore la cancelación del contexto
+func run(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, chk.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- chk.checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: StatusOK, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusError
+		level := slog.LevelError
+		if chk.optional {
+			result.Status, level = StatusDegraded, slog.LevelWarn
+		}
+		logging.FromContext(ctx).Log(ctx, level, "Readyz: dependencia no disponible", "check", chk.name, "error", err)
+	}
+	return result
+}
+
+// Healthz indica que el proceso está vivo, sin consultar dependencias
+// @Summary Estado del proceso
+// @Description Responde mientras el proceso esté vivo. Pensado para el liveness probe de Kubernetes
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Proceso vivo"
+// @Router /healthz [get]
+func (h *Handler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": StatusOK})
+}
+
+// Readyz verifica las dependencias y responde 503 solo si falla una obligatoria
+// @Summary Estado de las dependencias
+// @Description Verifica la conexión con PostgreSQL, las credenciales de Firebase, el bucket de Storage y el servidor SMTP. Pensado para el readiness probe de Kubernetes: solo una falla de PostgreSQL responde 503; las demás dependencias se informan como degraded. El detalle de las fallas queda en los logs
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report "Dependencias obligatorias disponibles (status ok o degraded)"
+// @Failure 503 {object} health.Report "PostgreSQL no está disponible"
+// @Router /readyz [get]
+func (h *Handler) Readyz(c *gin.Context) {
+	report := h.Run(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == StatusError {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// cached reutiliza el último resultado de una verificación durante ttl
+type cached struct {
+	checker Checker
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	err     error
+}
+
+// Cached envuelve una verificación costosa o con límites de uso, como iniciar sesión en el
+// servidor SMTP, para que los probes frecuentes no la repitan antes de ttl
+func Cached(checker Checker, ttl time.Duration) Checker {
+	return &cached{checker: checker, ttl: ttl}
+}
+
+func (c *cached) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checked.IsZero() && time.Since(c.checked) < c.ttl {
+		return c.err
+	}
+	c.err = c.checker.Check(ctx)
+	c.checked = time.Now()
+	return c.err
+}
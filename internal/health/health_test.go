@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadyz(t *testing.T) {
+	ok := CheckerFunc(func(context.Context) error { return nil })
+	caido := CheckerFunc(func(context.Context) error {
+		return errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	})
+
+	tests := []struct {
+		name       string
+		db         Checker
+		smtp       Checker
+		wantCode   int
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name: "todo disponible", db: ok, smtp: ok,
+			wantCode: http.StatusOK, wantStatus: StatusOK,
+			wantChecks: map[string]string{"postgres": StatusOK, "smtp": StatusOK},
+		},
+		{
+			name: "una dependencia opcional caída degrada sin sacar el pod", db: ok, smtp: caido,
+			wantCode: http.StatusOK, wantStatus: StatusDegraded,
+			wantChecks: map[string]string{"postgres": StatusOK, "smtp": StatusDegraded},
+		},
+		{
+			name: "la base de datos caída deja el servicio fuera", db: caido, smtp: caido,
+			wantCode: http.StatusServiceUnavailable, wantStatus: StatusError,
+			wantChecks: map[string]string{"postgres": StatusError, "smtp": StatusDegraded},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler()
+			h.Add("postgres", tt.db, 0)
+			h.AddOptional("smtp", tt.smtp, 0)
+
+			report := h.Run(context.Background())
+			if report.Status != tt.wantStatus {
+				t.Errorf("status = %q, se esperaba %q", report.Status, tt.wantStatus)
+			}
+			for name, want := range tt.wantChecks {
+				if got := report.Checks[name].Status; got != want {
+					t.Errorf("checks[%s] = %q, se esperaba %q", name, got, want)
+				}
+			}
+
+			router := gin.New()
+			router.GET("/readyz", h.Readyz)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("código HTTP = %d, se esperaba %d", rec.Code, tt.wantCode)
+			}
+			if strings.Contains(rec.Body.String(), "10.0.0.5") {
+				t.Errorf("la respuesta expone el detalle del error: %s", rec.Body.String())
+			}
+		})
+	}
+}
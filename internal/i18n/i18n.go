@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default es el idioma usado cuando no se puede negociar otro y como respaldo de claves faltantes
+const Default = "es"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// bundles contiene los mensajes de cada idioma, indexados por clave
+var bundles = map[string]map[string]string{}
+
+func init() {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range files {
+		data, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: %s inválido: %v", f.Name(), err))
+		}
+		bundles[strings.TrimSuffix(f.Name(), ".json")] = messages
+	}
+	if _, ok := bundles[Default]; !ok {
+		panic("i18n: falta el idioma por defecto " + Default)
+	}
+}
+
+// Supported devuelve los idiomas disponibles ordenados
+func Supported() []string {
+	langs := make([]string, 0, len(bundles))
+	for lang := range bundles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Normalize reduce una etiqueta de idioma (ej. "en-US") al idioma soportado; vacío si no hay
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if base, _, ok := strings.Cut(tag, "-"); ok {
+		tag = base
+	}
+	if _, ok := bundles[tag]; ok {
+		return tag
+	}
+	return ""
+}
+
+// Negotiate elige el idioma soportado de mayor preferencia en un encabezado Accept-Language
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, -1.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if lang := Normalize(tag); lang != "" && q > 0 && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Has indica si el idioma tiene un mensaje para la clave, sin usar el respaldo
+func Has(lang, key string) bool {
+	_, ok := bundles[lang][key]
+	return ok
+}
+
+// T traduce la clave al idioma indicado. Si falta, usa el idioma por defecto y, en último caso,
+// devuelve la clave. params son pares nombre/valor que reemplazan {nombre} en el mensaje
+func T(lang, key string, params ...any) string {
+	msg, ok := bundles[lang][key]
+	if !ok {
+		msg, ok = bundles[Default][key]
+	}
+	if !ok {
+		return key
+	}
+
+	for i := 0; i+1 < len(params); i += 2 {
+		msg = strings.ReplaceAll(msg, "{"+fmt.Sprint(params[i])+"}", fmt.Sprint(params[i+1]))
+	}
+	return msg
+}
+
+// Check verifica que todos los idiomas tengan exactamente las mismas claves
+func Check() error {
+	all := map[string]bool{}
+	for _, messages := range bundles {
+		for key := range messages {
+			all[key] = true
+		}
+	}
+
+	var problems []string
+	for _, lang := range Supported() {
+		for key := range all {
+			if !Has(lang, key) {
+				problems = append(problems, lang+": "+key)
+			}
+		}
+	}
+	return missingError(problems)
+}
+
+// CheckKeys verifica que todas las claves indicadas existan en todos los idiomas
+func CheckKeys(keys []string) error {
+	var problems []string
+	for _, lang := range Supported() {
+		for _, key := range keys {
+			if !Has(lang, key) {
+				problems = append(problems, lang+": "+key)
+			}
+		}
+	}
+	return missingError(problems)
+}
+
+func missingError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("faltan traducciones: %s", strings.Join(problems, ", "))
+}
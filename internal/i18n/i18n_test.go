@@ -0,0 +1,9 @@
+package i18n
+
+import "testing"
+
+func TestTodosLosIdiomasTienenLasMismasClaves(t *testing.T) {
+	if err := Check(); err != nil {
+		t.Fatal(err)
+	}
+}
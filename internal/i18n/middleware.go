@@ -0,0 +1,26 @@
+package i18n
+
+import "github.com/gin-gonic/gin"
+
+const contextKey = "lang"
+
+// Middleware elige el idioma de la respuesta según Accept-Language
+func Middleware(c *gin.Context) {
+	c.Header("Vary", "Accept-Language")
+	SetLanguage(c, Negotiate(c.GetHeader("Accept-Language")))
+	c.Next()
+}
+
+// SetLanguage fija el idioma de la solicitud, por ejemplo con la preferencia guardada del usuario
+func SetLanguage(c *gin.Context, lang string) {
+	c.Set(contextKey, lang)
+	c.Header("Content-Language", lang)
+}
+
+// FromContext devuelve el idioma de la solicitud o el idioma por defecto
+func FromContext(c *gin.Context) string {
+	if lang := c.GetString(contextKey); lang != "" {
+		return lang
+	}
+	return Default
+}
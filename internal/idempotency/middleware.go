@@ -0,0 +1,206 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/logging"
+	"login/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HeaderKey es el encabezado con el que el cliente identifica una solicitud reintentable
+const HeaderKey = "Idempotency-Key"
+
+// HeaderReplayed indica que la respuesta es la repetición de una solicitud anterior
+const HeaderReplayed = "Idempotent-Replayed"
+
+const (
+	defaultTTL   = 24 * time.Hour
+	maxKeyLength = 255
+	// leaseDuration es la vigencia de una clave en proceso. Si el proceso muere sin liberarla,
+	// la clave se puede volver a reservar al vencer, en lugar de bloquear los reintentos por todo el TTL
+	leaseDuration = 2 * time.Minute
+	// maxBodyBytes limita el cuerpo que se lee en memoria para calcular el hash de la solicitud
+	maxBodyBytes = 10 << 20
+)
+
+// Store guarda las claves de idempotencia y las respuestas asociadas
+type Store struct {
+	db  *gorm.DB
+	ttl time.Duration // tiempo durante el cual se guarda la respuesta
+}
+
+// NewStore crea el almacén de claves de idempotencia sobre la base de datos. Un ttl de cero
+// usa el valor por defecto de 24 horas
+func NewStore(db *gorm.DB, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Store{db: db, ttl: ttl}
+}
+
+// responseRecorder copia la respuesta del handler para poder repetirla
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Middleware hace que los POST con Idempotency-Key devuelvan la respuesta original al reintentarse
+// en lugar de ejecutarse otra vez. En rutas protegidas debe ir después de AuthMiddleware para
+// que la clave quede asociada al usuario
+func (s *Store) Middleware(c *gin.Context) {
+	key := c.GetHeader(HeaderKey)
+	if key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxKeyLength {
+		apierror.Abort(c, apierror.IdempotencyKeyTooLong)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apierror.Abort(c, apierror.RequestTooLarge)
+			return
+		}
+		apierror.Abort(c, apierror.InvalidRequest.Wrap(err))
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	clave := scopedKey(c, key)
+	hash := sha256.Sum256(body)
+	hashSolicitud := hex.EncodeToString(hash[:])
+
+	registro, reservado, err := s.reserve(c.Request.Context(), clave, hashSolicitud)
+	if err != nil {
+		logging.From(c).Error("Idempotencia: error al reservar la clave", "error", err)
+		c.Next()
+		return
+	}
+
+	if !reservado {
+		switch {
+		case registro.Hash_solicitud != hashSolicitud:
+			apierror.Abort(c, apierror.IdempotencyKeyReused)
+		case registro.Estado == models.EstadoIdempotenciaEnProceso:
+			apierror.Abort(c, apierror.IdempotencyKeyInProgress)
+		default:
+			c.Header(HeaderReplayed, "true")
+			c.Data(registro.Status, registro.Content_type, registro.Cuerpo)
+			c.Abort()
+		}
+		return
+	}
+
+	// El resultado se guarda aunque el cliente se haya desconectado
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	// Si el handler entra en pánico la clave se libera antes de que el pánico siga hacia el
+	// middleware de recuperación, para que el reintento no reciba un conflicto
+	completada := false
+	defer func() {
+		if !completada {
+			s.release(ctx, clave)
+		}
+	}()
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	// Los errores del servidor se consideran transitorios: se libera la clave para permitir el reintento
+	status := recorder.Status()
+	if status >= http.StatusInternalServerError {
+		return
+	}
+
+	err = s.db.WithContext(ctx).Model(&models.Idempotencia{}).Where("clave = ?", clave).Updates(map[string]interface{}{
+		"estado":       models.EstadoIdempotenciaCompletada,
+		"status":       status,
+		"content_type": recorder.Header().Get("Content-Type"),
+		"cuerpo":       recorder.body.Bytes(),
+		"expira_en":    time.Now().Add(s.ttl),
+	}).Error
+	if err != nil {
+		logging.From(c).Error("Idempotencia: error al guardar la respuesta", "error", err)
+		return
+	}
+	completada = true
+}
+
+// release elimina la clave en proceso para que el cliente pueda reintentar
+func (s *Store) release(ctx context.Context, clave string) {
+	err := s.db.WithContext(ctx).Delete(&models.Idempotencia{}, "clave = ? AND estado = ?", clave, models.EstadoIdempotenciaEnProceso).Error
+	if err != nil {
+		logging.FromContext(ctx).Error("Idempotencia: error al liberar la clave", "error", err)
+	}
+}
+
+// scopedKey asocia la clave a la ruta y, si existe, al usuario autenticado
+func scopedKey(c *gin.Context, key string) string {
+	uid, _ := c.Get("uid")
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%v|%s", c.Request.Method, c.FullPath(), uid, key)))
+	return hex.EncodeToString(sum[:])
+}
+
+// reserve intenta registrar la clave como en proceso con una vigencia de leaseDuration. Si ya
+// existe devuelve el registro guardado; los registros vencidos, incluidas las claves en proceso
+// abandonadas, se eliminan y la clave se vuelve a reservar
+func (s *Store) reserve(ctx context.Context, clave, hashSolicitud string) (*models.Idempotencia, bool, error) {
+	for intento := 0; intento < 2; intento++ {
+		nuevo := models.Idempotencia{
+			Clave:          clave,
+			Hash_solicitud: hashSolicitud,
+			Estado:         models.EstadoIdempotenciaEnProceso,
+			Expira_en:      time.Now().Add(leaseDuration),
+		}
+		result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&nuevo)
+		if result.Error != nil {
+			return nil, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return &nuevo, true, nil
+		}
+
+		var existente models.Idempotencia
+		if err := s.db.WithContext(ctx).Where("clave = ?", clave).First(&existente).Error; err != nil {
+			return nil, false, err
+		}
+		if time.Now().Before(existente.Expira_en) {
+			return &existente, false, nil
+		}
+		s.db.WithContext(ctx).Delete(&models.Idempotencia{}, "clave = ? AND expira_en <= ?", clave, time.Now())
+	}
+	return nil, false, fmt.Errorf("no se pudo reservar la clave de idempotencia")
+}
+
+// Purge elimina las claves vencidas
+func (s *Store) Purge(ctx context.Context) error {
+	return s.db.WithContext(ctx).Where("expira_en <= ?", time.Now()).Delete(&models.Idempotencia{}).Error
+}
@@ -0,0 +1,52 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const purgeInterval = time.Hour
+
+// Purger elimina periódicamente las claves de idempotencia vencidas
+type Purger struct {
+	store *Store
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// NewPurger crea la limpieza periódica del almacén
+func NewPurger(store *Store) *Purger {
+	return &Purger{store: store}
+}
+
+// Start inicia la limpieza en segundo plano
+func (p *Purger) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.store.Purge(ctx); err != nil {
+					slog.Error("Idempotencia: error al eliminar claves vencidas", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop detiene la limpieza
+func (p *Purger) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.done.Wait()
+}
@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderRequestID identifica la solicitud en los logs; se acepta del cliente o del proxy y se
+// devuelve en la respuesta
+const HeaderRequestID = "X-Request-ID"
+
+const contextKey = "logger"
+
+// validRequestID limita los ids aceptados del cliente para no registrar texto arbitrario
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// Middleware asigna el request id, guarda el logger de la solicitud en el contexto de gin y en
+// el de la solicitud, y registra cada solicitud con su ruta, estado, latencia y usuario. Las
+// solicitudes exitosas a las rutas quiet se registran en nivel debug, para no llenar los logs
+// con las sondas de Kubernetes
+func Middleware(quiet ...string) gin.HandlerFunc {
+	quietRoutes := make(map[string]bool, len(quiet))
+	for _, route := range quiet {
+		quietRoutes[route] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(HeaderRequestID)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Header(HeaderRequestID, id)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), requestIDKey{}, id))
+
+		attrs := []any{"request_id", id}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.HasTraceID() {
+			attrs = append(attrs, "trace_id", span.TraceID().String())
+		}
+		set(c, slog.Default().With(attrs...))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietRoutes[c.FullPath()]:
+			level = slog.LevelDebug
+		}
+
+		// Se registra la ruta y no la URL para no guardar tokens de la query ni uids del path
+		From(c).Log(c.Request.Context(), level, "solicitud",
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"bytes", c.Writer.Size(),
+		)
+	}
+}
+
+// With agrega atributos al logger del resto de la solicitud, por ejemplo el uid una vez
+// autenticado el usuario
+func With(c *gin.Context, args ...any) {
+	set(c, From(c).With(args...))
+}
+
+// From devuelve el logger de la solicitud
+func From(c *gin.Context) *slog.Logger {
+	if v, ok := c.Get(contextKey); ok {
+		return v.(*slog.Logger)
+	}
+	if c.Request == nil {
+		return slog.Default()
+	}
+	return FromContext(c.Request.Context())
+}
+
+// RequestID devuelve el request id guardado en el contexto, o vacío
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type requestIDKey struct{}
+
+// set guarda el logger en el contexto de gin y en el de la solicitud, para las funciones que
+// solo reciben un context.Context
+func set(c *gin.Context, logger *slog.Logger) {
+	c.Set(contextKey, logger)
+	c.Request = c.Request.WithContext(WithLogger(c.Request.Context(), logger))
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Transport propaga el request id de la solicitud en curso a las llamadas HTTP salientes
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripper{next: next}
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := RequestID(req.Context()); id != "" && req.Header.Get(HeaderRequestID) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(HeaderRequestID, id)
+	}
+	return t.next.RoundTrip(req)
+}
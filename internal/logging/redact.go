@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// redacted reemplaza el valor de los atributos sensibles
+const redacted = "[REDACTED]"
+
+// sensitiveKeys son los nombres de atributo cuyo valor nunca se registra. Se comparan con cada
+// parte del nombre separada por _ . o -, ej. correo_contacto, y con su final, ej. idToken. Un
+// nombre que solo los contiene, como correos, no coincide
+var sensitiveKeys = []string{
+	"password", "contrasena", "contraseña", "token", "secret", "authorization", "cookie",
+	"apikey", "api_key", "email", "correo", "phone", "telefono", "teléfono",
+}
+
+// identifierPrefix marca los atributos que son identificadores internos, como id_correo o
+// id_usuario: no contienen el dato personal y sirven para rastrear el registro
+const identifierPrefix = "id_"
+
+// patterns reemplazan los datos personales y credenciales que aparecen dentro de los textos,
+// por ejemplo en los mensajes de error de Firebase o de PostgreSQL
+var patterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), "[email]"},
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`), "[token]"},
+	{regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`), "[token]"},
+	{regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=\-]+`), "Bearer [token]"},
+	{regexp.MustCompile(`(?i)\b(password|contrase(?:ñ|n)a|token|secret|api_?key|key|oobCode|idToken|refreshToken)(["']?\s*[:=]\s*["']?)[^\s"'&,;}]+`), "${1}${2}" + redacted},
+	// Números internacionales con +, celulares chilenos (56 9XXXX XXXX sin + o 9XXXX XXXX) y
+	// celulares colombianos de 10 dígitos
+	{regexp.MustCompile(`\+\d{1,3}[\s.\-]?\(?\d{1,4}\)?(?:[\s.\-]?\d{2,4}){2,4}`), "[phone]"},
+	{regexp.MustCompile(`\b56[\s.\-]?9[\s.\-]?\d{4}[\s.\-]?\d{4}\b`), "[phone]"},
+	{regexp.MustCompile(`\b9[\s.\-]?\d{4}[\s.\-]?\d{4}\b`), "[phone]"},
+	{regexp.MustCompile(`\b3\d{2}[\s.\-]?\d{3}[\s.\-]?\d{4}\b`), "[phone]"},
+}
+
+// Redact oculta correos, teléfonos, tokens y contraseñas dentro de un texto
+func Redact(s string) string {
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// redactHandler aplica Redact al mensaje y a los atributos antes de escribir cada registro
+type redactHandler struct {
+	next slog.Handler
+}
+
+func (h redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	clean := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		clean.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, clean)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		clean[i] = redactAttr(a)
+	}
+	return redactHandler{next: h.next.WithAttrs(clean)}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{next: h.next.WithGroup(name)}
+}
+
+// redactAttr oculta el valor completo de los atributos sensibles (salvo banderas como
+// email_verified) y aplica Redact a los textos
+func redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindBool && sensitiveKey(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		clean := make([]any, len(group))
+		for i, g := range group {
+			clean[i] = redactAttr(g)
+		}
+		return slog.Group(a.Key, clean...)
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			return slog.String(a.Key, Redact(v.Error()))
+		case []byte:
+			return slog.String(a.Key, Redact(string(v)))
+		case fmt.Stringer:
+			return slog.String(a.Key, Redact(v.String()))
+		}
+	}
+	return a
+}
+
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasPrefix(key, identifierPrefix) {
+		return false
+	}
+	partes := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '.' || r == '-' })
+	for _, s := range sensitiveKeys {
+		if strings.HasSuffix(key, s) {
+			return true
+		}
+		for _, parte := range partes {
+			if parte == s {
+				return true
+			}
+		}
+	}
+	return false
+}
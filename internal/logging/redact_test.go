@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "correo", in: "usuario ana.perez@alumnos.utalca.cl no existe", want: "usuario [email] no existe"},
+		{name: "JWT", in: "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJ1aWQifQ.firma", want: "token [token]"},
+		{name: "bearer", in: "Authorization: Bearer abc.def-123", want: "Authorization: Bearer [token]"},
+		{name: "contraseña en clave=valor", in: `password="Secreta123"`, want: `password="[REDACTED]"`},
+		{name: "celular chileno con + y espacios", in: "llamar al +56 9 1234 5678", want: "llamar al [phone]"},
+		{name: "celular chileno con + sin espacios", in: "llamar al +56912345678", want: "llamar al [phone]"},
+		{name: "celular chileno con 56 sin +", in: "llamar al 56 9 1234 5678", want: "llamar al [phone]"},
+		{name: "celular chileno con 56 junto", in: "llamar al 56912345678", want: "llamar al [phone]"},
+		{name: "celular chileno de 9 dígitos", in: "llamar al 912345678", want: "llamar al [phone]"},
+		{name: "celular chileno con separadores", in: "llamar al 9 1234 5678", want: "llamar al [phone]"},
+		{name: "celular colombiano", in: "llamar al 300 123 4567", want: "llamar al [phone]"},
+		{name: "RUT no es un teléfono", in: "rut 9.876.543-2", want: "rut 9.876.543-2"},
+		{name: "números cortos no cambian", in: "status=500 latency_ms=912", want: "status=500 latency_ms=912"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.in); got != tt.want {
+				t.Errorf("Redact(%q) = %q, se esperaba %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(redactHandler{next: slog.NewJSONHandler(&buf, nil)})
+
+	logger.With("email", "ana@utalca.cl").
+		WithGroup("solicitud").
+		Info("Registro fallido para ana@utalca.cl",
+			"password", "Secreta123",
+			"email_verified", true,
+			"error", errors.New("EMAIL_EXISTS: ana@utalca.cl, teléfono +56 9 8765 4321"),
+			slog.Group("contacto", "telefono", "912345678", "nota", "escribir a luis@utalca.cl"),
+			"detalle", []byte("token=abc123"),
+			"uid", "uid-42",
+			"id_correo", 1234,
+			"correos", 3,
+		)
+
+	out := buf.String()
+	for _, secreto := range []string{"ana@utalca.cl", "Secreta123", "8765", "912345678", "luis@utalca.cl", "abc123"} {
+		if strings.Contains(out, secreto) {
+			t.Errorf("el log contiene %q:\n%s", secreto, out)
+		}
+	}
+	// Las banderas y los identificadores no sensibles se conservan
+	for _, esperado := range []string{`"email_verified":true`, `"uid":"uid-42"`, `"id_correo":1234`, `"correos":3`, "EMAIL_EXISTS", "[phone]", "[email]"} {
+		if !strings.Contains(out, esperado) {
+			t.Errorf("el log no contiene %q:\n%s", esperado, out)
+		}
+	}
+}
+
+func TestSensitiveKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"new_password", true},
+		{"newPassword", true},
+		{"idToken", true},
+		{"Authorization", true},
+		{"correo", true},
+		{"correo_contacto", true},
+		{"smtp.password", true},
+		{"email", true},
+		{"id_correo", false},
+		{"id_usuario", false},
+		{"correos", false},
+		{"estado", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := sensitiveKey(tt.key); got != tt.want {
+				t.Errorf("sensitiveKey(%q) = %v, se esperaba %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
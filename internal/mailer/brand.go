@@ -0,0 +1,80 @@
+package mailer
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Brand agrupa los elementos visuales de cada aplicación hermana
+type Brand struct {
+	Key          string
+	Name         string
+	SenderName   string
+	URL          string
+	LogoURL      string
+	PrimaryColor string
+	AccentColor  string
+}
+
+// DefaultBrand es la marca usada cuando no se reconoce la aplicación de origen
+const DefaultBrand = "ulink"
+
+var brands = map[string]Brand{
+	"ulink": {
+		Key:          "ulink",
+		Name:         "ULink",
+		SenderName:   "ULink",
+		URL:          "https://ulink.tssw.info",
+		LogoURL:      "https://ulink.tssw.info/logo.png",
+		PrimaryColor: "#1f3a93",
+		AccentColor:  "#f5a623",
+	},
+	"practicas": {
+		Key:          "practicas",
+		Name:         "ULink Prácticas",
+		SenderName:   "ULink Prácticas",
+		URL:          "https://practicas.tssw.info",
+		LogoURL:      "https://practicas.tssw.info/logo.png",
+		PrimaryColor: "#0b6e4f",
+		AccentColor:  "#f2c14e",
+	},
+	"descuentos": {
+		Key:          "descuentos",
+		Name:         "ULink Descuentos",
+		SenderName:   "ULink Descuentos",
+		URL:          "https://descuentos.tssw.info",
+		LogoURL:      "https://descuentos.tssw.info/logo.png",
+		PrimaryColor: "#c0392b",
+		AccentColor:  "#2c3e50",
+	},
+	"roomies": {
+		Key:          "roomies",
+		Name:         "ULink Roomies",
+		SenderName:   "ULink Roomies",
+		URL:          "https://roomies.tssw.info",
+		LogoURL:      "https://roomies.tssw.info/logo.png",
+		PrimaryColor: "#6c3483",
+		AccentColor:  "#48c9b0",
+	},
+}
+
+// BrandFor devuelve la marca de la aplicación indicada o la marca por defecto
+func BrandFor(app string) Brand {
+	if b, ok := brands[strings.ToLower(app)]; ok {
+		return b
+	}
+	return brands[DefaultBrand]
+}
+
+// AppFromOrigin deduce la aplicación a partir del Origin o Referer (ej. https://roomies.tssw.info)
+func AppFromOrigin(origin string) string {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	sub := strings.SplitN(u.Hostname(), ".", 2)[0]
+	if _, ok := brands[sub]; ok {
+		return sub
+	}
+	return ""
+}
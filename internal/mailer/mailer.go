@@ -0,0 +1,122 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"login/pkg/config"
+)
+
+// Message representa un correo a enviar
+type Message struct {
+	To       []string
+	Subject  string
+	Text     string
+	HTML     string // opcional; si existe se envía como multipart/alternative
+	FromName string // opcional; reemplaza el nombre de remitente configurado
+}
+
+// Mailer es la interfaz común de todos los backends de correo
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New construye el backend indicado en la configuración (smtp, console, file o memory)
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Backend {
+	case "smtp":
+		return &SMTPMailer{
+			Host:     cfg.Host,
+			Port:     strconv.Itoa(cfg.Port),
+			Username: cfg.User,
+			Password: cfg.Password,
+			TLSMode:  TLSMode(cfg.TLS),
+			From:     cfg.From,
+			FromName: cfg.FromName,
+		}, nil
+	case "console":
+		return &ConsoleMailer{From: cfg.From, FromName: cfg.FromName}, nil
+	case "file":
+		return NewFileMailer(cfg.OutboxDir, cfg.From, cfg.FromName)
+	case "memory":
+		return &MemoryMailer{From: cfg.From, FromName: cfg.FromName}, nil
+	default:
+		return nil, fmt.Errorf("backend de correo desconocido: %s", cfg.Backend)
+	}
+}
+
+// formatAddress arma la dirección del remitente con su nombre visible
+func formatAddress(from, fromName string) string {
+	if fromName == "" {
+		return from
+	}
+	return (&mail.Address{Name: fromName, Address: from}).String()
+}
+
+// Build arma el mensaje en formato RFC 5322 listo para enviarse o guardarse
+func (m Message) Build(from, fromName string) []byte {
+	if m.FromName != "" {
+		fromName = m.FromName
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", formatAddress(from, fromName))
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if m.HTML == "" {
+		writePart(&buf, "text/plain; charset=UTF-8", m.Text)
+		return buf.Bytes()
+	}
+
+	mw := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		w, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		qp := quotedprintable.NewWriter(w)
+		qp.Write([]byte(part.body))
+		qp.Close()
+	}
+	mw.Close()
+	return buf.Bytes()
+}
+
+// writePart escribe un cuerpo de una sola parte con codificación quoted-printable
+func writePart(buf *bytes.Buffer, contentType, body string) {
+	fmt.Fprintf(buf, "Content-Type: %s\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(buf)
+	qp.Write([]byte(body))
+	qp.Close()
+}
+
+// ConsoleMailer escribe los correos en la salida de errores en lugar de enviarlos, útil en desarrollo
+type ConsoleMailer struct {
+	From     string
+	FromName string
+}
+
+// Send imprime el mensaje completo en la salida de errores. Se escribe fuera del logger para
+// que la redacción no oculte los enlaces y códigos durante el desarrollo; no usar en producción
+func (m *ConsoleMailer) Send(_ context.Context, msg Message) error {
+	fmt.Fprintf(os.Stderr, "Correo (console):\n%s\n", msg.Build(m.From, m.FromName))
+	return nil
+}
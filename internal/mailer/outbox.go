@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileMailer guarda cada correo como un archivo .eml en un directorio
+type FileMailer struct {
+	Dir      string
+	From     string
+	FromName string
+}
+
+// NewFileMailer crea el directorio de salida si no existe
+func NewFileMailer(dir, from, fromName string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creando el directorio de salida de correos: %v", err)
+	}
+	return &FileMailer{Dir: dir, From: from, FromName: fromName}, nil
+}
+
+// Send escribe el mensaje en un archivo con marca de tiempo
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	return os.WriteFile(filepath.Join(m.Dir, name), msg.Build(m.From, m.FromName), 0o644)
+}
+
+// MemoryMailer guarda los correos en memoria para inspeccionarlos en pruebas
+type MemoryMailer struct {
+	From     string
+	FromName string
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Send agrega el mensaje a la bandeja en memoria
+func (m *MemoryMailer) Send(_ context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages devuelve una copia de los mensajes enviados
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Reset vacía la bandeja en memoria
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
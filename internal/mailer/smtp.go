@@ -0,0 +1,124 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"login/internal/metrics"
+	"login/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// TLSMode indica cómo se protege la conexión con el servidor SMTP
+type TLSMode string
+
+const (
+	TLSStartTLS TLSMode = "starttls" // conexión en claro que se eleva con STARTTLS (puerto 587)
+	TLSImplicit TLSMode = "tls"      // conexión TLS desde el inicio (puerto 465)
+	TLSNone     TLSMode = "none"     // sin cifrado, solo para relays internos sin autenticación
+)
+
+// SMTPMailer envía correos a través de un servidor SMTP configurable
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	TLSMode  TLSMode
+	From     string
+	FromName string
+}
+
+// connect abre la sesión SMTP con el cifrado configurado y se autentica si hay usuario
+func (m *SMTPMailer) connect(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.Host, m.Port)
+	dialer := &net.Dialer{}
+
+	var conn net.Conn
+	var err error
+	if m.TLSMode == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: m.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error conectando al servidor SMTP: %v", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error iniciando la sesión SMTP: %v", err)
+	}
+
+	if m.TLSMode == TLSStartTLS || m.TLSMode == "" {
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("error iniciando STARTTLS: %v", err)
+		}
+	}
+
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("error de autenticación SMTP: %v", err)
+		}
+	}
+	return client, nil
+}
+
+// Ping verifica que el servidor SMTP acepte la conexión y las credenciales configuradas
+func (m *SMTPMailer) Ping(ctx context.Context) error {
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.Quit()
+}
+
+// Send entrega el mensaje al servidor SMTP
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) (err error) {
+	ctx, span := tracing.Start(ctx, "smtp.send", semconv.ServerAddress(m.Host), attribute.Int("smtp.recipients", len(msg.To)))
+	defer func(start time.Time) {
+		tracing.End(span, err)
+		metrics.ObserveDependency(metrics.DependencySMTP, "send", start, err)
+	}(time.Now())
+
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Mail(m.From); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Build(m.From, m.FromName)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,136 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"login/internal/i18n"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Nombres de las plantillas disponibles
+const (
+	TemplateVerification    = "verification"
+	TemplatePasswordReset   = "password_reset"
+	TemplateWelcome         = "welcome"
+	TemplateCompanyApproval = "company_approval"
+	TemplateSecurityAlert   = "security_alert"
+)
+
+// Data son los valores disponibles dentro de una plantilla; Brand se agrega al renderizar
+type Data map[string]any
+
+// Templates devuelve los nombres de todas las plantillas registradas
+func Templates() []string {
+	return []string{TemplateVerification, TemplatePasswordReset, TemplateWelcome, TemplateCompanyApproval, TemplateSecurityAlert}
+}
+
+// Render construye el mensaje (asunto, texto y HTML) de una plantilla en el idioma y con la marca indicados.
+// Los textos de las plantillas se obtienen de los paquetes de i18n con la función t; el asunto usa la
+// clave email.<plantilla>.subject
+func Render(name, lang string, brand Brand, data Data) (Message, error) {
+	return render(name, lang, brand, data, nil)
+}
+
+// render permite registrar las claves que no existen en el idioma pedido
+func render(name, lang string, brand Brand, data Data, missing func(key string)) (Message, error) {
+	if !isTemplate(name) {
+		return Message{}, fmt.Errorf("plantilla desconocida: %s", name)
+	}
+
+	values := Data{}
+	for k, v := range data {
+		values[k] = v
+	}
+	values["Brand"] = brand
+	values["Lang"] = lang
+
+	t := func(key string, params ...any) string {
+		if missing != nil && !i18n.Has(lang, key) {
+			missing(key)
+		}
+		return i18n.T(lang, key, params...)
+	}
+
+	var textBuf, htmlBuf bytes.Buffer
+
+	textTmpl, err := texttemplate.New(name+".txt").Funcs(texttemplate.FuncMap{"t": t}).
+		ParseFS(templatesFS, "templates/"+name+".txt")
+	if err != nil {
+		return Message{}, fmt.Errorf("error cargando plantilla de texto %s: %v", name, err)
+	}
+	if err := textTmpl.Execute(&textBuf, values); err != nil {
+		return Message{}, err
+	}
+
+	htmlTmpl, err := htmltemplate.New("layout.html").Funcs(htmltemplate.FuncMap{"t": t}).
+		ParseFS(templatesFS, "templates/layout.html", "templates/"+name+".html")
+	if err != nil {
+		return Message{}, fmt.Errorf("error cargando plantilla HTML %s: %v", name, err)
+	}
+	if err := htmlTmpl.ExecuteTemplate(&htmlBuf, "layout", values); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject:  t("email."+name+".subject", "brand", brand.Name),
+		Text:     textBuf.String(),
+		HTML:     htmlBuf.String(),
+		FromName: brand.SenderName,
+	}, nil
+}
+
+func isTemplate(name string) bool {
+	for _, t := range Templates() {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTranslations renderiza cada plantilla en todos los idiomas con los datos de ejemplo
+// y devuelve un error con las claves que faltan
+func CheckTranslations() error {
+	var problems []string
+	for _, lang := range i18n.Supported() {
+		for _, name := range Templates() {
+			_, err := render(name, lang, BrandFor(DefaultBrand), SampleData(name), func(key string) {
+				problems = append(problems, lang+": "+key)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("faltan traducciones en las plantillas de correo: %s", strings.Join(problems, ", "))
+}
+
+// SampleData devuelve datos de ejemplo para previsualizar una plantilla
+func SampleData(name string) Data {
+	switch name {
+	case TemplateVerification:
+		return Data{"Link": "https://api-ulink.tssw.info/verify-email?token=ejemplo", "Code": "123456", "ExpiraMinutos": 15}
+	case TemplatePasswordReset:
+		return Data{"Link": "https://ulink.tssw.info/reset-password?token=ejemplo", "ExpiraMinutos": 60}
+	case TemplateWelcome:
+		return Data{"Nombre": "Camila"}
+	case TemplateCompanyApproval:
+		return Data{"NombreEmpresa": "Empresa de Ejemplo SpA"}
+	case TemplateSecurityAlert:
+		return Data{"Evento": "Cambio de contraseña", "Fecha": "19-10-2026 12:00", "IP": "200.1.2.3"}
+	default:
+		return Data{}
+	}
+}
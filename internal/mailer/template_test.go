@@ -0,0 +1,9 @@
+package mailer
+
+import "testing"
+
+func TestPlantillasTraducidas(t *testing.T) {
+	if err := CheckTranslations(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+// GormPlugin mide la duración de las consultas de GORM en dependency_call_duration_seconds
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return "metrics" }
+
+// Initialize registra los callbacks antes y después de cada tipo de operación
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	before := func(tx *gorm.DB) { tx.InstanceSet(gormStartKey, time.Now()) }
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			if start, ok := tx.InstanceGet(gormStartKey); ok {
+				err := tx.Error
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					err = nil
+				}
+				ObserveDependency(DependencyPostgres, operation, start.(time.Time), err)
+			}
+		}
+	}
+
+	cb := db.Callback()
+	for _, reg := range []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	} {
+		if err := reg.before("metrics:before_"+reg.operation, before); err != nil {
+			return err
+		}
+		if err := reg.after("metrics:after_"+reg.operation, after(reg.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"login/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// OutcomeSuccess es el resultado de las operaciones exitosas; los errores se etiquetan con su
+// código de apierror para que los paneles distingan, por ejemplo, credenciales incorrectas de
+// cuentas suspendidas
+const OutcomeSuccess = "success"
+
+// Registry contiene todas las métricas de la API y las del proceso
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Solicitudes HTTP atendidas por método, ruta y estado.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duración de las solicitudes HTTP por método y ruta.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// Registrations cuenta los registros por tipo de cuenta y resultado
+	Registrations = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_registrations_total",
+		Help: "Registros de cuentas por tipo de cuenta y resultado.",
+	}, []string{"account_type", "outcome"})
+
+	// Logins cuenta los inicios de sesión por tipo de cuenta y resultado
+	Logins = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Inicios de sesión por tipo de cuenta y resultado.",
+	}, []string{"account_type", "outcome"})
+
+	// Verifications cuenta las verificaciones de correo por método (link o code) y resultado
+	Verifications = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_email_verifications_total",
+		Help: "Verificaciones de correo por método y resultado.",
+	}, []string{"method", "outcome"})
+
+	// Uploads cuenta las subidas de imágenes por resultado
+	Uploads = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "uploads_total",
+		Help: "Subidas de imágenes por resultado.",
+	}, []string{"outcome"})
+
+	dependencyDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "dependency_call_duration_seconds",
+		Help:    "Duración de las llamadas a Firebase, SMTP, Storage y PostgreSQL por operación y resultado.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"dependency", "operation", "outcome"})
+)
+
+// Dependencias medidas en dependency_call_duration_seconds
+const (
+	DependencyFirebase = "firebase"
+	DependencySMTP     = "smtp"
+	DependencyStorage  = "storage"
+	DependencyPostgres = "postgres"
+)
+
+// ObserveDependency registra la duración de una llamada a un servicio externo desde start
+func ObserveDependency(dependency, operation string, start time.Time, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = "error"
+	}
+	dependencyDuration.WithLabelValues(dependency, operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+// Middleware mide todas las solicitudes HTTP. La ruta es el patrón registrado en gin, no la URL,
+// para que los parámetros no multipliquen las series
+func Middleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+	httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+}
+
+// Count incrementa counter con las etiquetas indicadas más el resultado de la solicitud. Debe ir
+// después de idempotency.Middleware para no contar las respuestas repetidas
+func Count(counter *prometheus.CounterVec, labels ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		counter.WithLabelValues(append(labels, Outcome(c))...).Inc()
+	}
+}
+
+// Outcome devuelve el resultado de la solicitud: success o el código de error respondido
+func Outcome(c *gin.Context) string {
+	if code := apierror.CodeFromContext(c); code != "" {
+		return code
+	}
+	if c.Writer.Status() >= 400 {
+		return "http_" + strconv.Itoa(c.Writer.Status())
+	}
+	return OutcomeSuccess
+}
+
+// Handler publica las métricas en el formato de Prometheus en /metrics
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+	return mux
+}
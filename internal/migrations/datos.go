@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"login/internal/academico"
+	"login/internal/perfil"
+
+	"gorm.io/gorm"
+)
+
+// dataMigrations son los pasos que transforman datos existentes y no se expresan bien en SQL.
+// Sus versiones se intercalan con las de los archivos SQL
+var dataMigrations = []Migration{
+	{
+		// Conserva las carreras referenciadas por usuarios antes de crear la llave foránea (0010)
+		Version: 9,
+		Name:    "carrera_referencias",
+		up:      academico.MigrateCarreraReferences,
+	},
+	{
+		// Traslada la antigua columna Carrera.facultad a la jerarquía creada en 0011
+		Version: 12,
+		Name:    "facultades",
+		up:      academico.MigrateFacultades,
+		down: func(tx *gorm.DB) error {
+			return tx.Exec(`ALTER TABLE "Carrera" ADD COLUMN IF NOT EXISTS facultad text;
+				UPDATE "Carrera" c SET facultad = f.nombre
+				FROM "Sede" s JOIN "Facultad" f ON f.id_facultad = s.id_facultad
+				WHERE c.id_sede = s.id_sede`).Error
+		},
+	},
+	{
+		// Convierte la fecha de nacimiento y el año de ingreso de texto a DATE y SMALLINT
+		Version: 15,
+		Name:    "perfil_conversion",
+		up:      perfil.MigrateTypedFields,
+		down: func(tx *gorm.DB) error {
+			return tx.Exec(`ALTER TABLE "Usuario"
+				ALTER COLUMN fecha_nacimiento TYPE text USING to_char(fecha_nacimiento, 'YYYY-MM-DD'),
+				ALTER COLUMN ano_ingreso TYPE text USING ano_ingreso::text`).Error
+		},
+	},
+}
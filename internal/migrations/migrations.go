@@ -0,0 +1,300 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var archivos embed.FS
+
+const (
+	// Table es la tabla donde se registran las migraciones aplicadas
+	Table = "schema_migrations"
+
+	// lockKey identifica el advisory lock de Postgres que serializa las migraciones entre
+	// réplicas. Se toma por transacción para que funcione también detrás de un pooler
+	lockKey = 7_310_420_042
+)
+
+var nombreArchivo = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration es un paso versionado del esquema. Los pasos SQL vienen de sql/NNNN_nombre.up.sql y
+// .down.sql; los pasos de datos se registran en Go. Un down nil no revierte datos
+type Migration struct {
+	Version int64
+	Name    string
+
+	up   func(tx *gorm.DB) error
+	down func(tx *gorm.DB) error
+}
+
+// String devuelve la versión y el nombre con el formato de los archivos
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status es el estado de una migración en la base de datos
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+	Unknown   bool // Aplicada en la base de datos pero ausente en este binario
+}
+
+type registro struct {
+	Version     int64
+	Nombre      string
+	Aplicada_en time.Time
+}
+
+// All devuelve todas las migraciones ordenadas por versión
+func All() ([]Migration, error) {
+	porVersion := map[int64]*Migration{}
+	archivosSQL := map[int64]map[string]bool{}
+
+	nombres, err := fs.Glob(archivos, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	for _, ruta := range nombres {
+		nombre := ruta[len("sql/"):]
+		partes := nombreArchivo.FindStringSubmatch(nombre)
+		if partes == nil {
+			return nil, fmt.Errorf("nombre de migración inválido: %s", nombre)
+		}
+		version, _ := strconv.ParseInt(partes[1], 10, 64)
+		m, ok := porVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: partes[2]}
+			porVersion[version] = m
+			archivosSQL[version] = map[string]bool{}
+		} else if m.Name != partes[2] {
+			return nil, fmt.Errorf("la versión %d tiene nombres distintos: %s y %s", version, m.Name, partes[2])
+		}
+
+		contenido, err := archivos.ReadFile(ruta)
+		if err != nil {
+			return nil, err
+		}
+		paso := execSQL(string(contenido))
+		if partes[3] == "up" {
+			m.up = paso
+		} else {
+			m.down = paso
+		}
+		archivosSQL[version][partes[3]] = true
+	}
+	for version, direcciones := range archivosSQL {
+		if !direcciones["up"] || !direcciones["down"] {
+			return nil, fmt.Errorf("la migración %s debe tener archivos .up.sql y .down.sql", porVersion[version])
+		}
+	}
+
+	for _, m := range dataMigrations {
+		if _, ok := porVersion[m.Version]; ok {
+			return nil, fmt.Errorf("la versión %d está duplicada", m.Version)
+		}
+		m := m
+		porVersion[m.Version] = &m
+	}
+
+	todas := make([]Migration, 0, len(porVersion))
+	for _, m := range porVersion {
+		todas = append(todas, *m)
+	}
+	sort.Slice(todas, func(i, j int) bool { return todas[i].Version < todas[j].Version })
+	return todas, nil
+}
+
+func execSQL(sql string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(sql).Error
+	}
+}
+
+// lock toma el advisory lock hasta el fin de la transacción
+func lock(tx *gorm.DB) error {
+	return tx.Exec(fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", lockKey)).Error
+}
+
+func ensureTable(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+		return tx.Exec(`CREATE TABLE IF NOT EXISTS ` + Table + ` (
+			version bigint PRIMARY KEY,
+			nombre text NOT NULL,
+			aplicada_en timestamptz NOT NULL DEFAULT now()
+		)`).Error
+	})
+}
+
+func applied(db *gorm.DB) (map[int64]registro, error) {
+	aplicadas := map[int64]registro{}
+	if !db.Migrator().HasTable(Table) {
+		return aplicadas, nil
+	}
+	var registros []registro
+	if err := db.Table(Table).Find(&registros).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range registros {
+		aplicadas[r.Version] = r
+	}
+	return aplicadas, nil
+}
+
+// Up aplica en orden todas las migraciones pendientes y devuelve cuántas aplicó. Cada migración
+// corre en su propia transacción con el advisory lock tomado, y se omite si otra réplica ya la aplicó
+func Up(db *gorm.DB) (int, error) {
+	todas, err := All()
+	if err != nil {
+		return 0, err
+	}
+	if err := ensureTable(db); err != nil {
+		return 0, err
+	}
+
+	aplicadas := 0
+	for _, m := range todas {
+		aplicada := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+			var existe int64
+			if err := tx.Table(Table).Where("version = ?", m.Version).Count(&existe).Error; err != nil {
+				return err
+			}
+			if existe > 0 {
+				return nil
+			}
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			aplicada = true
+			return tx.Exec("INSERT INTO "+Table+" (version, nombre) VALUES (?, ?)", m.Version, m.Name).Error
+		})
+		if err != nil {
+			return aplicadas, fmt.Errorf("migración %s: %w", m, err)
+		}
+		if aplicada {
+			aplicadas++
+			slog.Info("Migración aplicada", "migracion", m)
+		}
+	}
+	return aplicadas, nil
+}
+
+// Down revierte las últimas migraciones aplicadas, de la más reciente a la más antigua
+func Down(db *gorm.DB, pasos int) (int, error) {
+	todas, err := All()
+	if err != nil {
+		return 0, err
+	}
+	porVersion := map[int64]Migration{}
+	for _, m := range todas {
+		porVersion[m.Version] = m
+	}
+	if !db.Migrator().HasTable(Table) {
+		return 0, nil
+	}
+
+	revertidas := 0
+	for revertidas < pasos {
+		var revertida *Migration
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+			var ultima []registro
+			if err := tx.Table(Table).Order("version DESC").Limit(1).Find(&ultima).Error; err != nil {
+				return err
+			}
+			if len(ultima) == 0 {
+				return nil
+			}
+			m, ok := porVersion[ultima[0].Version]
+			if !ok {
+				return fmt.Errorf("la versión %d no existe en este binario", ultima[0].Version)
+			}
+			if m.down != nil {
+				if err := m.down(tx); err != nil {
+					return fmt.Errorf("migración %s: %w", m, err)
+				}
+			} else {
+				slog.Warn("Migración: los cambios de datos no se revierten", "migracion", m)
+			}
+			revertida = &m
+			return tx.Exec("DELETE FROM "+Table+" WHERE version = ?", m.Version).Error
+		})
+		if err != nil {
+			return revertidas, err
+		}
+		if revertida == nil {
+			break
+		}
+		revertidas++
+		slog.Info("Migración revertida", "migracion", revertida)
+	}
+	return revertidas, nil
+}
+
+// StatusAll devuelve el estado de cada migración conocida y de las aplicadas que este binario no conoce
+func StatusAll(db *gorm.DB) ([]Status, error) {
+	todas, err := All()
+	if err != nil {
+		return nil, err
+	}
+	aplicadas, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	estados := make([]Status, 0, len(todas))
+	for _, m := range todas {
+		estado := Status{Migration: m}
+		if r, ok := aplicadas[m.Version]; ok {
+			estado.Applied = true
+			estado.AppliedAt = &r.Aplicada_en
+			delete(aplicadas, m.Version)
+		}
+		estados = append(estados, estado)
+	}
+	for _, r := range aplicadas {
+		aplicadaEn := r.Aplicada_en
+		estados = append(estados, Status{
+			Migration: Migration{Version: r.Version, Name: r.Nombre},
+			Applied:   true,
+			AppliedAt: &aplicadaEn,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(estados, func(i, j int) bool { return estados[i].Version < estados[j].Version })
+	return estados, nil
+}
+
+// Pending devuelve las migraciones que aún no se aplican
+func Pending(db *gorm.DB) ([]Migration, error) {
+	estados, err := StatusAll(db)
+	if err != nil {
+		return nil, err
+	}
+	var pendientes []Migration
+	for _, estado := range estados {
+		if !estado.Applied {
+			pendientes = append(pendientes, estado.Migration)
+		}
+	}
+	return pendientes, nil
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "Usuario_empresa";
+DROP TABLE IF EXISTS "Usuario";
@@ -0,0 +1,36 @@
+-- Tablas originales de cuentas. Usa IF NOT EXISTS porque las bases anteriores al
+-- versionado ya las crearon con AutoMigrate
+CREATE TABLE IF NOT EXISTS "Usuario" (
+    "id" bigserial,
+    "firebase_usuario" text,
+    "correo" text,
+    "nombres" text,
+    "apellidos" text,
+    "fecha_nacimiento" text,
+    "ano_ingreso" text,
+    "id_carrera" bigint,
+    "id_estado_usuario" boolean,
+    "foto_perfil" text,
+    "rol" text,
+    "perfil_completado" boolean,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Usuario_firebase_usuario" ON "Usuario" ("firebase_usuario");
+
+CREATE TABLE IF NOT EXISTS "Usuario_empresa" (
+    "id_empresa" bigserial,
+    "firebase_usuario_empresa" text,
+    "nombre_empresa" text,
+    "correo_empresa" text,
+    "sector" text,
+    "descripcion" text,
+    "direccion" text,
+    "persona_contacto" text,
+    "correo_contacto" text,
+    "telefono_contacto" bigint,
+    "estado_verificacion" bigint,
+    "perfil_completado" boolean,
+    "rol" text,
+    PRIMARY KEY ("id_empresa")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Usuario_empresa_firebase_usuario_empresa" ON "Usuario_empresa" ("firebase_usuario_empresa");
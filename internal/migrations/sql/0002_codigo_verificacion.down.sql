@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "Codigo_verificacion";
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "Codigo_verificacion" (
+    "id" bigserial,
+    "correo" text,
+    "codigo_hash" text,
+    "intentos" bigint DEFAULT 0,
+    "expira_en" timestamptz,
+    "usado" boolean DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_Codigo_verificacion_correo" ON "Codigo_verificacion" ("correo");
+CREATE INDEX IF NOT EXISTS "idx_Codigo_verificacion_expira_en" ON "Codigo_verificacion" ("expira_en");
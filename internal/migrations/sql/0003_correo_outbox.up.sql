@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "Correo_outbox" (
+    "id" bigserial,
+    "destinatario" text,
+    "asunto" text,
+    "texto" text,
+    "html" text,
+    "remitente" text,
+    "plantilla" text,
+    "estado" text DEFAULT 'pendiente',
+    "intentos" bigint DEFAULT 0,
+    "proximo_intento" timestamptz,
+    "ultimo_error" text,
+    "enviado_en" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_Correo_outbox_destinatario" ON "Correo_outbox" ("destinatario");
+CREATE INDEX IF NOT EXISTS "idx_Correo_outbox_estado" ON "Correo_outbox" ("estado");
+CREATE INDEX IF NOT EXISTS "idx_Correo_outbox_proximo_intento" ON "Correo_outbox" ("proximo_intento");
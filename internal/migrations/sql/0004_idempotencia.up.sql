@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "Idempotencia" (
+    "clave" text,
+    "hash_solicitud" text,
+    "estado" text,
+    "status" bigint,
+    "content_type" text,
+    "cuerpo" bytea,
+    "expira_en" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("clave")
+);
+CREATE INDEX IF NOT EXISTS "idx_Idempotencia_expira_en" ON "Idempotencia" ("expira_en");
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "Reset_contrasena" (
+    "id" bigserial,
+    "correo" text,
+    "token_hash" text,
+    "expira_en" timestamptz,
+    "usado_en" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_Reset_contrasena_correo" ON "Reset_contrasena" ("correo");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Reset_contrasena_token_hash" ON "Reset_contrasena" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_Reset_contrasena_expira_en" ON "Reset_contrasena" ("expira_en");
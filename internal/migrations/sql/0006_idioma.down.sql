@@ -0,0 +1,2 @@
+ALTER TABLE "Usuario_empresa" DROP COLUMN IF EXISTS "idioma";
+ALTER TABLE "Usuario" DROP COLUMN IF EXISTS "idioma";
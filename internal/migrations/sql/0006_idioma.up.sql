@@ -0,0 +1,2 @@
+ALTER TABLE "Usuario" ADD COLUMN IF NOT EXISTS "idioma" varchar(5);
+ALTER TABLE "Usuario_empresa" ADD COLUMN IF NOT EXISTS "idioma" varchar(5);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "Suspension_cuenta";
+ALTER TABLE "Usuario_empresa" DROP COLUMN IF EXISTS "suspendido";
+ALTER TABLE "Usuario" DROP COLUMN IF EXISTS "suspendido";
@@ -0,0 +1,17 @@
+ALTER TABLE "Usuario" ADD COLUMN IF NOT EXISTS "suspendido" boolean;
+ALTER TABLE "Usuario_empresa" ADD COLUMN IF NOT EXISTS "suspendido" boolean;
+
+CREATE TABLE IF NOT EXISTS "Suspension_cuenta" (
+    "id" bigserial,
+    "firebase_uid" text,
+    "tipo_cuenta" text,
+    "motivo" text,
+    "suspendida_por" text,
+    "expira_en" timestamptz,
+    "levantada_en" timestamptz,
+    "levantada_por" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_Suspension_cuenta_firebase_uid" ON "Suspension_cuenta" ("firebase_uid");
+CREATE INDEX IF NOT EXISTS "idx_Suspension_cuenta_expira_en" ON "Suspension_cuenta" ("expira_en");
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "Carrera" (
+    "id_carrera" bigserial,
+    "codigo" text NOT NULL,
+    "nombre" text NOT NULL,
+    "facultad" text,
+    "duracion_semestres" bigint,
+    "activa" boolean NOT NULL,
+    PRIMARY KEY ("id_carrera")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Carrera_codigo" ON "Carrera" ("codigo");
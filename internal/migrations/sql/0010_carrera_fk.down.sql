@@ -0,0 +1 @@
+ALTER TABLE "Usuario" DROP CONSTRAINT IF EXISTS "fk_Usuario_carrera";
@@ -0,0 +1,7 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_Usuario_carrera') THEN
+        ALTER TABLE "Usuario" ADD CONSTRAINT "fk_Usuario_carrera" FOREIGN KEY ("id_carrera")
+            REFERENCES "Carrera" ("id_carrera") ON DELETE RESTRICT ON UPDATE CASCADE;
+    END IF;
+END $$;
@@ -0,0 +1,4 @@
+ALTER TABLE "Carrera" DROP COLUMN IF EXISTS "id_sede";
+DROP TABLE IF EXISTS "Sede";
+DROP TABLE IF EXISTS "Facultad";
+DROP TABLE IF EXISTS "Universidad";
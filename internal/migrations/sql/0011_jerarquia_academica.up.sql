@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS "Universidad" (
+    "id_universidad" bigserial,
+    "codigo" text NOT NULL,
+    "nombre" text NOT NULL,
+    "activa" boolean NOT NULL,
+    PRIMARY KEY ("id_universidad")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Universidad_codigo" ON "Universidad" ("codigo");
+
+CREATE TABLE IF NOT EXISTS "Facultad" (
+    "id_facultad" bigserial,
+    "id_universidad" bigint NOT NULL,
+    "nombre" text NOT NULL,
+    "activa" boolean NOT NULL,
+    PRIMARY KEY ("id_facultad"),
+    CONSTRAINT "fk_Facultad_universidad" FOREIGN KEY ("id_universidad")
+        REFERENCES "Universidad" ("id_universidad") ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_Facultad_id_universidad" ON "Facultad" ("id_universidad");
+
+CREATE TABLE IF NOT EXISTS "Sede" (
+    "id_sede" bigserial,
+    "id_facultad" bigint NOT NULL,
+    "nombre" text NOT NULL,
+    "ciudad" text,
+    "activa" boolean NOT NULL,
+    PRIMARY KEY ("id_sede"),
+    CONSTRAINT "fk_Sede_facultad" FOREIGN KEY ("id_facultad")
+        REFERENCES "Facultad" ("id_facultad") ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_Sede_id_facultad" ON "Sede" ("id_facultad");
+
+ALTER TABLE "Carrera" ADD COLUMN IF NOT EXISTS "id_sede" bigint;
+CREATE INDEX IF NOT EXISTS "idx_Carrera_id_sede" ON "Carrera" ("id_sede");
@@ -0,0 +1 @@
+ALTER TABLE "Carrera" DROP CONSTRAINT IF EXISTS "fk_Carrera_sede";
@@ -0,0 +1,7 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_Carrera_sede') THEN
+        ALTER TABLE "Carrera" ADD CONSTRAINT "fk_Carrera_sede" FOREIGN KEY ("id_sede")
+            REFERENCES "Sede" ("id_sede") ON DELETE RESTRICT ON UPDATE CASCADE;
+    END IF;
+END $$;
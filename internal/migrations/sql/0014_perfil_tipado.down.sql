@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "Usuario_dato_invalido";
+ALTER TABLE "Carrera" DROP COLUMN IF EXISTS "ano_apertura";
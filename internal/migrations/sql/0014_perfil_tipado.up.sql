@@ -0,0 +1,11 @@
+ALTER TABLE "Carrera" ADD COLUMN IF NOT EXISTS "ano_apertura" smallint;
+
+CREATE TABLE IF NOT EXISTS "Usuario_dato_invalido" (
+    "id" bigserial,
+    "id_usuario" bigint,
+    "columna" text,
+    "valor" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_Usuario_dato_invalido_id_usuario" ON "Usuario_dato_invalido" ("id_usuario");
@@ -0,0 +1,19 @@
+package models
+
+// Carrera es una carrera del catálogo que los usuarios eligen en su perfil. Se imparte en una sede
+type Carrera struct {
+	Id_carrera         uint   `gorm:"primaryKey;autoIncrement" json:"Id_carrera"`
+	Id_sede            *uint  `gorm:"index" json:"Id_sede"`
+	Codigo             string `gorm:"type:text;uniqueIndex;not null" json:"Codigo"`
+	Nombre             string `gorm:"type:text;not null" json:"Nombre"`
+	Duracion_semestres uint   `json:"Duracion_semestres"`
+	Ano_apertura       *int16 `gorm:"type:smallint" json:"Ano_apertura"` // Año en que la carrera recibió su primera cohorte
+	Activa             bool   `gorm:"not null" json:"Activa"`
+
+	Sede *Sede `gorm:"belongsTo:true;foreignKey:Id_sede;references:Id_sede;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Carrera) TableName() string {
+	return "Carrera"
+}
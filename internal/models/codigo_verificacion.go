@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Codigo_verificacion guarda el hash de un código numérico de verificación de correo
+type Codigo_verificacion struct {
+	Id          uint      `gorm:"primaryKey;autoIncrement"`
+	Correo      string    `gorm:"type:text;index"`
+	Codigo_hash string    `gorm:"type:text"`
+	Intentos    int       `gorm:"default:0"`
+	Expira_en   time.Time `gorm:"index"`
+	Usado       bool      `gorm:"default:false"`
+	CreatedAt   time.Time
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Codigo_verificacion) TableName() string {
+	return "Codigo_verificacion"
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Estados posibles de un correo en la bandeja de salida
+const (
+	EstadoCorreoPendiente = "pendiente"
+	EstadoCorreoEnviando  = "enviando" // reservado por un worker hasta proximo_intento
+	EstadoCorreoEnviado   = "enviado"
+	EstadoCorreoFallido   = "fallido" // agotó los reintentos (dead letter)
+)
+
+// Correo_outbox es un correo pendiente de entrega, escrito en la misma transacción que la operación que lo origina
+type Correo_outbox struct {
+	Id              uint      `gorm:"primaryKey;autoIncrement"`
+	Destinatario    string    `gorm:"type:text;index"`
+	Asunto          string    `gorm:"type:text"`
+	Texto           string    `gorm:"type:text"`
+	Html            string    `gorm:"type:text"`
+	Remitente       string    `gorm:"type:text"`
+	Plantilla       string    `gorm:"type:text"`
+	Estado          string    `gorm:"type:text;index;default:pendiente"`
+	Intentos        int       `gorm:"default:0"`
+	Proximo_intento time.Time `gorm:"index"`
+	Ultimo_error    string    `gorm:"type:text"`
+	Enviado_en      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Correo_outbox) TableName() string {
+	return "Correo_outbox"
+}
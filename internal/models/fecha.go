@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// FormatoFecha es el formato de las fechas sin hora en la API
+const FormatoFecha = "2006-01-02"
+
+// Fecha es una fecha sin hora guardada como DATE y expuesta en JSON como "AAAA-MM-DD"
+type Fecha struct {
+	time.Time
+}
+
+// NuevaFecha crea una fecha a partir del año, mes y día
+func NuevaFecha(year int, month time.Month, day int) Fecha {
+	return Fecha{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// GormDataType indica a GORM el tipo de la columna
+func (Fecha) GormDataType() string {
+	return "date"
+}
+
+func (f Fecha) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + f.Format(FormatoFecha) + `"`), nil
+}
+
+func (f *Fecha) UnmarshalJSON(data []byte) error {
+	t, err := time.Parse(`"`+FormatoFecha+`"`, string(data))
+	if err != nil {
+		return fmt.Errorf("fecha inválida, se espera AAAA-MM-DD: %s", data)
+	}
+	f.Time = t
+	return nil
+}
+
+// Value guarda la fecha en la base de datos
+func (f Fecha) Value() (driver.Value, error) {
+	return f.Format(FormatoFecha), nil
+}
+
+// Scan lee la fecha desde la base de datos
+func (f *Fecha) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		f.Time = NuevaFecha(v.Year(), v.Month(), v.Day()).Time
+		return nil
+	case string:
+		t, err := time.Parse(FormatoFecha, v)
+		f.Time = t
+		return err
+	case []byte:
+		t, err := time.Parse(FormatoFecha, string(v))
+		f.Time = t
+		return err
+	default:
+		return fmt.Errorf("no se puede leer %T como fecha", value)
+	}
+}
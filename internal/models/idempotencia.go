@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Estados de una clave de idempotencia
+const (
+	EstadoIdempotenciaEnProceso  = "en_proceso"
+	EstadoIdempotenciaCompletada = "completada"
+)
+
+// Idempotencia guarda la respuesta de una solicitud POST asociada a un encabezado Idempotency-Key
+type Idempotencia struct {
+	Clave          string `gorm:"primaryKey;type:text"`
+	Hash_solicitud string `gorm:"type:text"`
+	Estado         string `gorm:"type:text"`
+	Status         int
+	Content_type   string    `gorm:"type:text"`
+	Cuerpo         []byte    `gorm:"type:bytea"`
+	Expira_en      time.Time `gorm:"index"`
+	CreatedAt      time.Time
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Idempotencia) TableName() string {
+	return "Idempotencia"
+}
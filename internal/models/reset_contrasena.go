@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Reset_contrasena registra cada token de restablecimiento de contraseña para que sea de un solo uso
+type Reset_contrasena struct {
+	Id         uint      `gorm:"primaryKey;autoIncrement"`
+	Correo     string    `gorm:"type:text;index"`
+	Token_hash string    `gorm:"type:text;uniqueIndex"`
+	Expira_en  time.Time `gorm:"index"`
+	Usado_en   *time.Time
+	CreatedAt  time.Time
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Reset_contrasena) TableName() string {
+	return "Reset_contrasena"
+}
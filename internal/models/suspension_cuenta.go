@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Tipos de cuenta que pueden suspenderse
+const (
+	TipoCuentaUsuario = "usuario"
+	TipoCuentaEmpresa = "empresa"
+)
+
+// Suspension_cuenta registra cada suspensión aplicada por un administrador. La suspensión
+// está activa mientras Levantada_en sea nulo; Expira_en nulo indica una suspensión indefinida
+type Suspension_cuenta struct {
+	Id             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Firebase_uid   string     `gorm:"type:text;index" json:"firebase_uid"`
+	Tipo_cuenta    string     `gorm:"type:text" json:"tipo_cuenta"`
+	Motivo         string     `gorm:"type:text" json:"motivo"`
+	Suspendida_por string     `gorm:"type:text" json:"suspendida_por"`
+	Expira_en      *time.Time `gorm:"index" json:"expira_en,omitempty"`
+	Levantada_en   *time.Time `json:"levantada_en,omitempty"`
+	Levantada_por  string     `gorm:"type:text" json:"levantada_por,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Suspension_cuenta) TableName() string {
+	return "Suspension_cuenta"
+}
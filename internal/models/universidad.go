@@ -0,0 +1,45 @@
+package models
+
+// Universidad es el nivel superior de la jerarquía académica: Universidad > Facultad > Sede > Carrera
+type Universidad struct {
+	Id_universidad uint   `gorm:"primaryKey;autoIncrement" json:"Id_universidad"`
+	Codigo         string `gorm:"type:text;uniqueIndex;not null" json:"Codigo"`
+	Nombre         string `gorm:"type:text;not null" json:"Nombre"`
+	Activa         bool   `gorm:"not null" json:"Activa"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Universidad) TableName() string {
+	return "Universidad"
+}
+
+// Facultad pertenece a una universidad
+type Facultad struct {
+	Id_facultad    uint   `gorm:"primaryKey;autoIncrement" json:"Id_facultad"`
+	Id_universidad uint   `gorm:"not null;index" json:"Id_universidad"`
+	Nombre         string `gorm:"type:text;not null" json:"Nombre"`
+	Activa         bool   `gorm:"not null" json:"Activa"`
+
+	Universidad *Universidad `gorm:"belongsTo:true;foreignKey:Id_universidad;references:Id_universidad;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Facultad) TableName() string {
+	return "Facultad"
+}
+
+// Sede es el campus donde una facultad imparte sus carreras
+type Sede struct {
+	Id_sede     uint   `gorm:"primaryKey;autoIncrement" json:"Id_sede"`
+	Id_facultad uint   `gorm:"not null;index" json:"Id_facultad"`
+	Nombre      string `gorm:"type:text;not null" json:"Nombre"`
+	Ciudad      string `gorm:"type:text" json:"Ciudad"`
+	Activa      bool   `gorm:"not null" json:"Activa"`
+
+	Facultad *Facultad `gorm:"belongsTo:true;foreignKey:Id_facultad;references:Id_facultad;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Sede) TableName() string {
+	return "Sede"
+}
@@ -6,13 +6,17 @@ type Usuario struct {
 	Correo            string `json:"Correo"`
 	Nombres           string `json:"Nombres"`
 	Apellidos         string `json:"Apellidos"`
-	Fecha_nacimiento  string `json:"Fecha_Nacimiento"`
-	Ano_ingreso       string `json:"Ano_Ingreso"`
-	Id_carrera        uint   `json:"Id_carrera"`
+	Fecha_nacimiento  *Fecha `json:"Fecha_Nacimiento" swaggertype:"string" format:"date" example:"2001-03-15"`
+	Ano_ingreso       *int16 `gorm:"type:smallint" json:"Ano_Ingreso"`
+	Id_carrera        *uint  `json:"Id_carrera"`
 	Id_estado_usuario bool   `json:"Id_Estado_Usuario"`
 	Foto_perfil       string `json:"Foto_Perfil"`
 	Rol               string `json:"Rol"`
 	PerfilCompletado  bool   `json:"PerfilCompletado"`
+	Idioma            string `gorm:"size:5" json:"Idioma"` // Preferencia de idioma; vacío usa Accept-Language
+	Suspendido        bool   `json:"Suspendido"`           // Cuenta suspendida por un administrador
+
+	Carrera *Carrera `gorm:"belongsTo:true;foreignKey:Id_carrera;references:Id_carrera;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"Carrera,omitempty"`
 }
 
 // TableName establece el nombre de la tabla para GORM
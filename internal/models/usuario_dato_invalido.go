@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Usuario_dato_invalido conserva los valores que la migración de tipos no pudo interpretar,
+// para que puedan revisarse y corregirse a mano
+type Usuario_dato_invalido struct {
+	Id         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Id_usuario uint      `gorm:"index" json:"id_usuario"`
+	Columna    string    `gorm:"type:text" json:"columna"`
+	Valor      string    `gorm:"type:text" json:"valor"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName establece el nombre de la tabla para GORM
+func (Usuario_dato_invalido) TableName() string {
+	return "Usuario_dato_invalido"
+}
@@ -14,6 +14,8 @@ type Usuario_empresa struct {
 	Estado_verificacion      uint   `json:"Estado_verificacion"`
 	Perfil_Completado        bool   `json:"Perfil_Completado"`
 	Rol                      string `json:"Rol"`
+	Idioma                   string `gorm:"size:5" json:"Idioma"` // Preferencia de idioma; vacío usa Accept-Language
+	Suspendido               bool   `json:"Suspendido"`           // Cuenta suspendida por un administrador
 }
 
 // TableName establece el nombre de la tabla para GORM
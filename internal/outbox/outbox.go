@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"login/internal/logging"
+	"login/internal/mailer"
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Enqueue guarda el correo en la bandeja de salida usando la transacción recibida,
+// de modo que solo se envía si la operación que lo origina se confirma. Después del
+// commit conviene llamar a Worker.Wake para no esperar al siguiente sondeo del worker
+func Enqueue(tx *gorm.DB, plantilla string, msg mailer.Message) (*models.Correo_outbox, error) {
+	if len(msg.To) == 0 {
+		return nil, errors.New("el correo no tiene destinatarios")
+	}
+	correos := make([]models.Correo_outbox, 0, len(msg.To))
+	for _, to := range msg.To {
+		if strings.TrimSpace(to) == "" {
+			return nil, errors.New("el correo tiene un destinatario vacío")
+		}
+		correos = append(correos, models.Correo_outbox{
+			Destinatario:    to,
+			Asunto:          msg.Subject,
+			Texto:           msg.Text,
+			Html:            msg.HTML,
+			Remitente:       msg.FromName,
+			Plantilla:       plantilla,
+			Estado:          models.EstadoCorreoPendiente,
+			Proximo_intento: time.Now(),
+		})
+	}
+	if err := tx.Create(&correos).Error; err != nil {
+		return nil, err
+	}
+
+	return &correos[0], nil
+}
+
+// Status devuelve los correos de un destinatario, del más reciente al más antiguo
+func Status(db *gorm.DB, destinatario string, limit int) ([]models.Correo_outbox, error) {
+	var correos []models.Correo_outbox
+	err := db.Select("id", "destinatario", "asunto", "plantilla", "estado", "intentos", "proximo_intento", "ultimo_error", "enviado_en", "created_at", "updated_at").
+		Where("destinatario = ?", destinatario).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&correos).Error
+	// Las filas guardadas antes de ocultar los errores SMTP pueden traer el destinatario
+	for i := range correos {
+		correos[i].Ultimo_error = logging.Redact(correos[i].Ultimo_error)
+	}
+	return correos, err
+}
+
+// Message reconstruye el mensaje a partir de la fila de la bandeja de salida
+func Message(correo models.Correo_outbox) mailer.Message {
+	return mailer.Message{
+		To:       []string{correo.Destinatario},
+		Subject:  correo.Asunto,
+		Text:     correo.Texto,
+		HTML:     correo.Html,
+		FromName: correo.Remitente,
+	}
+}
@@ -0,0 +1,215 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"login/internal/logging"
+	"login/internal/mailer"
+	"login/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pollInterval = 10 * time.Second
+	batchSize    = 20
+	sendTimeout  = 30 * time.Second
+	baseBackoff  = 30 * time.Second
+	maxBackoff   = 2 * time.Hour
+	maxIntentos  = 8
+	// leaseDuration es el tiempo que un pod reserva los correos que tomó; debe superar el peor
+	// caso de un lote (batchSize × sendTimeout). Si el pod muere, otro los retoma al vencer
+	leaseDuration = 15 * time.Minute
+	// saveTimeout limita el guardado del resultado, que no se cancela con el apagado
+	saveTimeout = 10 * time.Second
+)
+
+// Worker entrega los correos pendientes con reintentos y backoff exponencial
+type Worker struct {
+	db     *gorm.DB
+	mailer mailer.Mailer
+	wake   chan struct{}
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// NewWorker crea un worker sobre la base de datos y el mailer indicados
+func NewWorker(db *gorm.DB, m mailer.Mailer) *Worker {
+	return &Worker{db: db, mailer: m, wake: make(chan struct{}, 1)}
+}
+
+// Wake despierta al worker para que procese la bandeja de salida de inmediato. Un worker nil
+// no hace nada, así los servicios pueden construirse sin worker en las pruebas
+func (w *Worker) Wake() {
+	if w == nil {
+		return
+	}
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start inicia el procesamiento en segundo plano
+func (w *Worker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done.Add(1)
+	go func() {
+		defer w.done.Done()
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			w.processBatch(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-w.wake:
+			}
+		}
+	}()
+	slog.Info("Worker de la bandeja de salida de correos iniciado")
+}
+
+// Stop detiene el worker y espera a que termine el lote en curso
+func (w *Worker) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.done.Wait()
+}
+
+// processBatch reserva un lote de correos vencidos y los envía fuera de la transacción. La
+// reserva se confirma antes de enviar, así un rollback o un apagado no repite un envío que ya
+// salió; los correos cuya reserva vence (pod caído) vuelven a tomarse
+func (w *Worker) processBatch(ctx context.Context) {
+	correos, err := w.claim(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Error procesando la bandeja de salida de correos", "error", err)
+		}
+		return
+	}
+
+	for i, correo := range correos {
+		if ctx.Err() != nil {
+			w.release(correos[i:])
+			return
+		}
+		w.save(w.deliver(ctx, correo))
+	}
+}
+
+// claim marca un lote como enviando con una reserva de leaseDuration, bloqueando las filas
+// para que otros pods no tomen las mismas. El intento se cuenta al reservar, de modo que un
+// correo que tumba el proceso no se reintenta para siempre
+func (w *Worker) claim(ctx context.Context) ([]models.Correo_outbox, error) {
+	var correos []models.Correo_outbox
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Where("estado IN ? AND proximo_intento <= ?", []string{models.EstadoCorreoPendiente, models.EstadoCorreoEnviando}, now).
+			Order("proximo_intento").
+			Limit(batchSize).
+			Find(&correos).Error
+		if err != nil || len(correos) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(correos))
+		for i := range correos {
+			correos[i].Estado = models.EstadoCorreoEnviando
+			correos[i].Proximo_intento = now.Add(leaseDuration)
+			correos[i].Intentos++
+			ids[i] = correos[i].Id
+		}
+		return tx.Model(&models.Correo_outbox{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"estado":          models.EstadoCorreoEnviando,
+			"proximo_intento": now.Add(leaseDuration),
+			"intentos":        gorm.Expr("intentos + 1"),
+		}).Error
+	})
+	return correos, err
+}
+
+// save guarda el resultado del envío. Usa un contexto que sobrevive al apagado para no perder
+// el estado de un correo que ya salió, y solo actualiza correos que siguen reservados
+func (w *Worker) save(correo *models.Correo_outbox) {
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+	err := w.db.WithContext(ctx).Model(&models.Correo_outbox{}).
+		Where("id = ? AND estado = ?", correo.Id, models.EstadoCorreoEnviando).
+		Updates(map[string]interface{}{
+			"estado":          correo.Estado,
+			"proximo_intento": correo.Proximo_intento,
+			"ultimo_error":    correo.Ultimo_error,
+			"enviado_en":      correo.Enviado_en,
+		}).Error
+	if err != nil {
+		slog.Error("Error guardando el resultado del envío", "id_correo", correo.Id, "estado", correo.Estado, "error", err)
+	}
+}
+
+// release devuelve a pendiente los correos reservados que no se alcanzaron a enviar por el
+// apagado, sin contarles el intento
+func (w *Worker) release(correos []models.Correo_outbox) {
+	ids := make([]uint, len(correos))
+	for i, correo := range correos {
+		ids[i] = correo.Id
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+	err := w.db.WithContext(ctx).Model(&models.Correo_outbox{}).
+		Where("id IN ? AND estado = ?", ids, models.EstadoCorreoEnviando).
+		Updates(map[string]interface{}{
+			"estado":          models.EstadoCorreoPendiente,
+			"proximo_intento": time.Now(),
+			"intentos":        gorm.Expr("intentos - 1"),
+		}).Error
+	if err != nil {
+		slog.Error("Error liberando los correos reservados", "correos", len(ids), "error", err)
+	}
+}
+
+// deliver intenta enviar un correo ya reservado y calcula su nuevo estado según el resultado
+func (w *Worker) deliver(ctx context.Context, correo models.Correo_outbox) *models.Correo_outbox {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	err := w.mailer.Send(sendCtx, Message(correo))
+	if err == nil {
+		now := time.Now()
+		correo.Estado = models.EstadoCorreoEnviado
+		correo.Enviado_en = &now
+		correo.Ultimo_error = ""
+		return &correo
+	}
+
+	// El error del servidor SMTP suele incluir el destinatario y se muestra en la vista de
+	// administración de la bandeja de salida, por eso se guarda oculto
+	correo.Ultimo_error = logging.Redact(err.Error())
+	if correo.Intentos >= maxIntentos {
+		correo.Estado = models.EstadoCorreoFallido
+		slog.Error("Correo descartado tras agotar los intentos", "id_correo", correo.Id, "intentos", correo.Intentos, "error", err)
+		return &correo
+	}
+
+	correo.Estado = models.EstadoCorreoPendiente
+	correo.Proximo_intento = time.Now().Add(backoff(correo.Intentos))
+	return &correo
+}
+
+// backoff calcula la espera antes del siguiente intento: 30s, 1m, 2m, ... hasta 2h
+func backoff(intentos int) time.Duration {
+	d := time.Duration(float64(baseBackoff) * math.Pow(2, float64(intentos-1)))
+	if d > maxBackoff || d <= 0 {
+		return maxBackoff
+	}
+	return d
+}
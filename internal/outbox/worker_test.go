@@ -0,0 +1,32 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"login/internal/mailer"
+	"login/internal/models"
+)
+
+// failingMailer rechaza todos los envíos con el error indicado
+type failingMailer struct{ err error }
+
+func (m failingMailer) Send(context.Context, mailer.Message) error { return m.err }
+
+func TestDeliverGuardaElErrorOculto(t *testing.T) {
+	w := NewWorker(nil, failingMailer{err: errors.New("550 5.1.1 <ana.perez@alumnos.utalca.cl>: Recipient address rejected")})
+
+	got := w.deliver(context.Background(), models.Correo_outbox{Id: 1, Destinatario: "ana.perez@alumnos.utalca.cl", Intentos: 1})
+
+	if got.Estado != models.EstadoCorreoPendiente {
+		t.Errorf("estado = %q, se esperaba %q", got.Estado, models.EstadoCorreoPendiente)
+	}
+	if strings.Contains(got.Ultimo_error, "ana.perez") {
+		t.Errorf("ultimo_error contiene el destinatario: %q", got.Ultimo_error)
+	}
+	if !strings.Contains(got.Ultimo_error, "550 5.1.1") || !strings.Contains(got.Ultimo_error, "[email]") {
+		t.Errorf("ultimo_error = %q, debe conservar el código SMTP", got.Ultimo_error)
+	}
+}
@@ -0,0 +1,78 @@
+package password
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var bloomMagic = [4]byte{'B', 'L', 'M', '1'}
+
+// BloomFilter es un filtro de Bloom de tamaño fijo. Puede dar falsos positivos
+// pero nunca falsos negativos
+type BloomFilter struct {
+	m    uint32 // cantidad de bits
+	k    uint32 // cantidad de funciones hash
+	bits []byte
+}
+
+// NewBloomFilter dimensiona el filtro para n elementos con la tasa de falsos positivos indicada
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+	m := uint32(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint32(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+	return &BloomFilter{m: m, k: k, bits: make([]byte, (m+7)/8)}
+}
+
+// positions calcula las posiciones de un elemento con doble hashing sobre SHA-256
+func (b *BloomFilter) positions(item string) []uint32 {
+	sum := sha256.Sum256([]byte(item))
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16])
+	pos := make([]uint32, b.k)
+	for i := uint32(0); i < b.k; i++ {
+		pos[i] = uint32((h1 + uint64(i)*h2) % uint64(b.m))
+	}
+	return pos
+}
+
+// Add agrega un elemento al filtro
+func (b *BloomFilter) Add(item string) {
+	for _, p := range b.positions(item) {
+		b.bits[p/8] |= 1 << (p % 8)
+	}
+}
+
+// Contains indica si el elemento probablemente está en el filtro
+func (b *BloomFilter) Contains(item string) bool {
+	for _, p := range b.positions(item) {
+		if b.bits[p/8]&(1<<(p%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary serializa el filtro: "BLM1", m y k en big endian y luego los bits
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 12+len(b.bits))
+	copy(out, bloomMagic[:])
+	binary.BigEndian.PutUint32(out[4:8], b.m)
+	binary.BigEndian.PutUint32(out[8:12], b.k)
+	copy(out[12:], b.bits)
+	return out, nil
+}
+
+// UnmarshalBinary carga un filtro serializado con MarshalBinary
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 12 || [4]byte(data[0:4]) != bloomMagic {
+		return errors.New("filtro de Bloom con formato inválido")
+	}
+	m := binary.BigEndian.Uint32(data[4:8])
+	k := binary.BigEndian.Uint32(data[8:12])
+	if m == 0 || k == 0 || uint32(len(data)-12) != (m+7)/8 {
+		return errors.New("filtro de Bloom con tamaño inválido")
+	}
+	b.m, b.k, b.bits = m, k, append([]byte(nil), data[12:]...)
+	return nil
+}
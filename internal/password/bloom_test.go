@@ -0,0 +1,92 @@
+package password
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	items := make([]string, 500)
+	for i := range items {
+		items[i] = fmt.Sprintf("clave-%d", i)
+	}
+	filter := NewBloomFilter(len(items), 0.001)
+	for _, item := range items {
+		filter.Add(item)
+	}
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &BloomFilter{}
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	for name, f := range map[string]*BloomFilter{"original": filter, "deserializado": loaded} {
+		t.Run(name, func(t *testing.T) {
+			for _, item := range items {
+				if !f.Contains(item) {
+					t.Fatalf("Contains(%q) = false; un filtro de Bloom no tiene falsos negativos", item)
+				}
+			}
+			// Con 0,1% de falsos positivos, entre 1000 elementos ausentes se esperan muy pocos
+			var falsos int
+			for i := range 1000 {
+				if f.Contains(fmt.Sprintf("ausente-%d", i)) {
+					falsos++
+				}
+			}
+			if falsos > 10 {
+				t.Errorf("falsos positivos = %d de 1000, se esperaban muy pocos", falsos)
+			}
+		})
+	}
+}
+
+func TestBloomFilterUnmarshalInvalido(t *testing.T) {
+	valido, _ := NewBloomFilter(10, 0.01).MarshalBinary()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "vacío", data: nil},
+		{name: "encabezado incompleto", data: valido[:8]},
+		{name: "firma incorrecta", data: append([]byte("XXXX"), valido[4:]...)},
+		{name: "bits truncados", data: valido[:len(valido)-1]},
+		{name: "cero funciones hash", data: append(append([]byte(nil), valido[:8]...), append([]byte{0, 0, 0, 0}, valido[12:]...)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (&BloomFilter{}).UnmarshalBinary(tt.data); err == nil {
+				t.Error("UnmarshalBinary no devolvió error")
+			}
+		})
+	}
+}
+
+func TestIsBreached(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"123456", true},
+		{"password", true},
+		{"PASSWORD", true},
+		{"  qwerty  ", true},
+		{"contraseña", true},
+		{"Gx7-lluvia-Talca", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			if got := IsBreached(tt.password); got != tt.want {
+				t.Errorf("IsBreached(%q) = %v, se esperaba %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
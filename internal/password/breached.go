@@ -0,0 +1,41 @@
+package password
+
+import (
+	_ "embed"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./gen -in common_passwords.txt -out breached.bloom
+
+// breachedBloom es el filtro generado a partir de common_passwords.txt
+//
+//go:embed breached.bloom
+var breachedBloom []byte
+
+var (
+	breachedOnce   sync.Once
+	breachedFilter *BloomFilter
+)
+
+// IsBreached indica si la contraseña aparece en la lista local de contraseñas comunes o filtradas
+func IsBreached(password string) bool {
+	breachedOnce.Do(func() {
+		f := &BloomFilter{}
+		if err := f.UnmarshalBinary(breachedBloom); err != nil {
+			slog.Error("Error cargando la lista de contraseñas filtradas", "error", err)
+			return
+		}
+		breachedFilter = f
+	})
+	if breachedFilter == nil {
+		return false
+	}
+	return breachedFilter.Contains(Normalize(password))
+}
+
+// Normalize es la forma en que se comparan las contraseñas contra la lista
+func Normalize(password string) string {
+	return strings.ToLower(strings.TrimSpace(password))
+}
@@ -0,0 +1,55 @@
+// Comando gen construye el filtro de Bloom embebido a partir de una lista de contraseñas,
+// una por línea. Se ejecuta con go generate desde internal/password
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"login/internal/password"
+)
+
+func main() {
+	in := flag.String("in", "common_passwords.txt", "lista de contraseñas, una por línea")
+	out := flag.String("out", "breached.bloom", "archivo de salida")
+	fpRate := flag.Float64("fp", 0.001, "tasa de falsos positivos")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("Error abriendo %s: %v", *in, err)
+	}
+	defer f.Close()
+
+	seen := map[string]bool{}
+	var items []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		item := password.Normalize(line)
+		if !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("Error leyendo %s: %v", *in, err)
+	}
+
+	filter := password.NewBloomFilter(len(items), *fpRate)
+	for _, item := range items {
+		filter.Add(item)
+	}
+
+	data, _ := filter.MarshalBinary()
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("Error escribiendo %s: %v", *out, err)
+	}
+	log.Printf("Filtro generado con %d contraseñas (%d bytes)", len(items), len(data))
+}
@@ -0,0 +1,127 @@
+package password
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reglas de la política de contraseñas
+const (
+	RuleMinLength    = "min_length"
+	RuleMaxLength    = "max_length"
+	RuleUpper        = "uppercase"
+	RuleLower        = "lowercase"
+	RuleDigit        = "digit"
+	RuleSymbol       = "symbol"
+	RulePersonalInfo = "personal_info"
+	RuleBreached     = "breached"
+)
+
+// Violation describe una regla que la contraseña no cumple. El mensaje para el usuario sale
+// del catálogo de i18n con la clave password.<regla>
+type Violation struct {
+	Rule string
+}
+
+// UserInfo son los datos personales que la contraseña no debe contener
+type UserInfo struct {
+	Email     string
+	Nombres   string
+	Apellidos string
+}
+
+// Policy define los requisitos de las contraseñas
+type Policy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// RejectPersonalInfo rechaza contraseñas que contengan el correo, nombres o apellidos
+	RejectPersonalInfo bool
+	// RejectBreached rechaza contraseñas de la lista local de contraseñas comunes o filtradas
+	RejectBreached bool
+}
+
+// DefaultPolicy es la política usada cuando no se configura otra
+var DefaultPolicy = Policy{
+	MinLength:          8,
+	MaxLength:          128,
+	RequireUpper:       true,
+	RequireLower:       true,
+	RequireDigit:       true,
+	RequireSymbol:      false,
+	RejectPersonalInfo: true,
+	RejectBreached:     true,
+}
+
+// Validate devuelve todas las reglas que la contraseña no cumple; vacío si es válida
+func (p Policy) Validate(password string, info UserInfo) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violations = append(violations, Violation{RuleMinLength})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, Violation{RuleMaxLength})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{RuleUpper})
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, Violation{RuleLower})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{RuleDigit})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{RuleSymbol})
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, info) {
+		violations = append(violations, Violation{RulePersonalInfo})
+	}
+	if p.RejectBreached && IsBreached(password) {
+		violations = append(violations, Violation{RuleBreached})
+	}
+
+	return violations
+}
+
+// containsPersonalInfo busca en la contraseña la parte local del correo y cada palabra de los nombres
+func containsPersonalInfo(password string, info UserInfo) bool {
+	lower := strings.ToLower(password)
+
+	var tokens []string
+	if local, _, ok := strings.Cut(strings.ToLower(info.Email), "@"); ok {
+		tokens = append(tokens, local)
+		tokens = append(tokens, strings.FieldsFunc(local, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })...)
+	}
+	tokens = append(tokens, strings.Fields(strings.ToLower(info.Nombres))...)
+	tokens = append(tokens, strings.Fields(strings.ToLower(info.Apellidos))...)
+
+	for _, token := range tokens {
+		// Las palabras muy cortas generan demasiados falsos positivos
+		if utf8.RuneCountInString(token) >= 3 && strings.Contains(lower, token) {
+			return true
+		}
+	}
+	return false
+}
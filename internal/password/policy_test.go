@@ -0,0 +1,66 @@
+package password
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// rules devuelve las reglas incumplidas en el orden en que Validate las informa
+func rules(violations []Violation) []string {
+	out := make([]string, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, v.Rule)
+	}
+	return out
+}
+
+func TestPolicyValidate(t *testing.T) {
+	ana := UserInfo{Email: "ana.perez@alumnos.utalca.cl", Nombres: "Ana María", Apellidos: "Pérez Soto"}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		info     UserInfo
+		want     []string
+	}{
+		{name: "largo mínimo no cumplido", policy: Policy{MinLength: 8}, password: "Ab1-xyz", want: []string{RuleMinLength}},
+		{name: "largo mínimo exacto", policy: Policy{MinLength: 8}, password: "Ab1-xyzw"},
+		{name: "el largo cuenta caracteres y no bytes", policy: Policy{MinLength: 8, MaxLength: 8}, password: "ñandúñañ"},
+		{name: "largo máximo superado", policy: Policy{MaxLength: 10}, password: "Ab1-xyzwvut", want: []string{RuleMaxLength}},
+		{name: "sin largo máximo", policy: Policy{}, password: strings.Repeat("Ab1-", 250)},
+		{name: "falta mayúscula", policy: Policy{RequireUpper: true}, password: "ab1-xyzw", want: []string{RuleUpper}},
+		{name: "mayúscula con tilde", policy: Policy{RequireUpper: true}, password: "Ñb1-xyzw"},
+		{name: "falta minúscula", policy: Policy{RequireLower: true}, password: "AB1-XYZW", want: []string{RuleLower}},
+		{name: "falta número", policy: Policy{RequireDigit: true}, password: "Abc-xyzw", want: []string{RuleDigit}},
+		{name: "falta símbolo", policy: Policy{RequireSymbol: true}, password: "Abc1xyzw", want: []string{RuleSymbol}},
+		{name: "el espacio cuenta como símbolo", policy: Policy{RequireSymbol: true}, password: "Abc1 xyzw"},
+		{name: "contiene la parte local del correo", policy: Policy{RejectPersonalInfo: true}, password: "Xana.perez9", info: ana, want: []string{RulePersonalInfo}},
+		{name: "contiene una palabra del correo", policy: Policy{RejectPersonalInfo: true}, password: "Perez-2024!", info: ana, want: []string{RulePersonalInfo}},
+		{name: "contiene un nombre", policy: Policy{RejectPersonalInfo: true}, password: "maría-Kx72", info: ana, want: []string{RulePersonalInfo}},
+		{name: "contiene un apellido", policy: Policy{RejectPersonalInfo: true}, password: "Qz7-SOTO-vw", info: ana, want: []string{RulePersonalInfo}},
+		{name: "las palabras cortas se ignoran", policy: Policy{RejectPersonalInfo: true}, password: "Li-Kx72vw", info: UserInfo{Nombres: "Li", Apellidos: "Wu"}},
+		{name: "datos personales permitidos", policy: Policy{}, password: "Xana.perez9", info: ana},
+		{name: "contraseña filtrada", policy: Policy{RejectBreached: true}, password: "qwerty", want: []string{RuleBreached}},
+		{name: "contraseña filtrada con mayúsculas y espacios", policy: Policy{RejectBreached: true}, password: " Contraseña ", want: []string{RuleBreached}},
+		{name: "contraseña filtrada permitida", policy: Policy{}, password: "qwerty"},
+		{name: "política por defecto válida", policy: DefaultPolicy, password: "Gx7-lluvia-Talca", info: ana},
+		{
+			name:     "política por defecto informa todas las reglas en orden",
+			policy:   DefaultPolicy,
+			password: "ana",
+			info:     ana,
+			want:     []string{RuleMinLength, RuleUpper, RuleDigit, RulePersonalInfo, RuleBreached},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules(tt.policy.Validate(tt.password, tt.info))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Validate(%q) = %v, se esperaba %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
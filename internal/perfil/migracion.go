@@ -0,0 +1,94 @@
+package perfil
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// columnaTipada describe una columna de Usuario guardada antes como texto
+type columnaTipada struct {
+	nombre string
+	tipo   string
+	parse  func(string) (interface{}, bool)
+}
+
+var columnasTipadas = []columnaTipada{
+	{"fecha_nacimiento", "DATE", func(v string) (interface{}, bool) { return ParseFecha(v) }},
+	{"ano_ingreso", "SMALLINT", func(v string) (interface{}, bool) { return ParseAno(v) }},
+}
+
+// MigrateTypedFields convierte Usuario.fecha_nacimiento a DATE y Usuario.ano_ingreso a SMALLINT.
+// Los valores que no se pueden interpretar quedan en NULL y se registran en Usuario_dato_invalido
+// para revisarlos a mano. Cada columna se convierte en una transacción y solo si sigue siendo texto
+func MigrateTypedFields(db *gorm.DB) error {
+	tipos, err := db.Migrator().ColumnTypes(&models.Usuario{})
+	if err != nil {
+		return err
+	}
+	esTexto := map[string]bool{}
+	for _, tipo := range tipos {
+		nombre := strings.ToLower(tipo.DatabaseTypeName())
+		esTexto[tipo.Name()] = nombre == "text" || nombre == "varchar"
+	}
+
+	for _, columna := range columnasTipadas {
+		if !esTexto[columna.nombre] {
+			continue
+		}
+		if err := db.Transaction(func(tx *gorm.DB) error { return migrarColumna(tx, columna) }); err != nil {
+			return fmt.Errorf("columna %s: %w", columna.nombre, err)
+		}
+	}
+	return nil
+}
+
+func migrarColumna(tx *gorm.DB, columna columnaTipada) error {
+	temporal := columna.nombre + "_tipado"
+	if err := tx.Exec(fmt.Sprintf(`ALTER TABLE "Usuario" ADD COLUMN %s %s`, temporal, columna.tipo)).Error; err != nil {
+		return err
+	}
+
+	var filas []struct {
+		Id    uint
+		Valor string
+	}
+	err := tx.Raw(fmt.Sprintf(`SELECT id, %s AS valor FROM "Usuario" WHERE TRIM(COALESCE(%s, '')) <> ''`, columna.nombre, columna.nombre)).
+		Scan(&filas).Error
+	if err != nil {
+		return err
+	}
+
+	var invalidos int
+	for _, fila := range filas {
+		valor, ok := columna.parse(fila.Valor)
+		if !ok {
+			invalidos++
+			// El valor es un dato personal: solo queda en Usuario_dato_invalido, no en los logs
+			slog.Warn("Perfil: valor inválido", "id_usuario", fila.Id, "columna", columna.nombre)
+			dato := models.Usuario_dato_invalido{Id_usuario: fila.Id, Columna: columna.nombre, Valor: fila.Valor}
+			if err := tx.Create(&dato).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Exec(fmt.Sprintf(`UPDATE "Usuario" SET %s = ? WHERE id = ?`, temporal), valor, fila.Id).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(fmt.Sprintf(`ALTER TABLE "Usuario" DROP COLUMN %s`, columna.nombre)).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec(fmt.Sprintf(`ALTER TABLE "Usuario" RENAME COLUMN %s TO %s`, temporal, columna.nombre)).Error; err != nil {
+		return err
+	}
+
+	slog.Info("Perfil: columna convertida; los inválidos quedan en Usuario_dato_invalido",
+		"columna", columna.nombre, "tipo", columna.tipo, "validos", len(filas)-invalidos, "invalidos", invalidos)
+	return nil
+}
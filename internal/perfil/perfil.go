@@ -0,0 +1,121 @@
+package perfil
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"login/internal/apierror"
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	EdadMinima    = 15   // Edad mínima para registrarse en la plataforma
+	EdadMaxima    = 100  // Edad máxima plausible para una fecha de nacimiento
+	AnoIngresoMin = 1900 // Primer año de ingreso aceptado cuando la carrera no indica su apertura
+)
+
+// formatosFecha son los formatos aceptados para la fecha de nacimiento. El primero es el oficial
+// de la API; los demás son los que aparecen en los datos antiguos guardados como texto
+var formatosFecha = []string{models.FormatoFecha, "02-01-2006", "02/01/2006", "2006/01/02"}
+
+// ParseFecha interpreta una fecha en cualquiera de los formatos aceptados
+func ParseFecha(valor string) (models.Fecha, bool) {
+	valor = strings.TrimSpace(valor)
+	for _, formato := range formatosFecha {
+		if t, err := time.Parse(formato, valor); err == nil {
+			return models.NuevaFecha(t.Year(), t.Month(), t.Day()), true
+		}
+	}
+	return models.Fecha{}, false
+}
+
+// ParseAno interpreta un año de cuatro dígitos
+func ParseAno(valor string) (int16, bool) {
+	valor = strings.TrimSpace(valor)
+	if len(valor) != 4 {
+		return 0, false
+	}
+	ano, err := strconv.ParseInt(valor, 10, 16)
+	if err != nil {
+		return 0, false
+	}
+	return int16(ano), true
+}
+
+// Edad calcula los años cumplidos a la fecha hoy
+func Edad(nacimiento models.Fecha, hoy time.Time) int {
+	edad := hoy.Year() - nacimiento.Year()
+	if hoy.Month() < nacimiento.Month() || (hoy.Month() == nacimiento.Month() && hoy.Day() < nacimiento.Day()) {
+		edad--
+	}
+	return edad
+}
+
+// Datos son los campos tipados del perfil ya validados. Los nil no se modifican
+type Datos struct {
+	Fecha_nacimiento *models.Fecha
+	Ano_ingreso      *int16
+}
+
+// Validar interpreta y valida la fecha de nacimiento y el año de ingreso enviados como texto.
+// Los campos vacíos se omiten. idCarrera es la carrera contra la que se valida el año de ingreso
+// (0 si el usuario no tiene carrera). Todos los problemas se devuelven juntos como errores por campo
+func Validar(db *gorm.DB, fechaNacimiento, anoIngreso string, idCarrera uint) (Datos, error) {
+	var datos Datos
+	var campos []apierror.FieldError
+	hoy := time.Now()
+
+	if strings.TrimSpace(fechaNacimiento) != "" {
+		fecha, ok := ParseFecha(fechaNacimiento)
+		switch {
+		case !ok:
+			campos = append(campos, apierror.Field("fecha_nacimiento", "format", "field.date_format"))
+		case Edad(fecha, hoy) < EdadMinima || Edad(fecha, hoy) > EdadMaxima:
+			campos = append(campos, apierror.Field("fecha_nacimiento", "age_range", "field.age_range", "min", EdadMinima, "max", EdadMaxima))
+		default:
+			datos.Fecha_nacimiento = &fecha
+		}
+	}
+
+	if strings.TrimSpace(anoIngreso) != "" {
+		ano, ok := ParseAno(anoIngreso)
+		switch {
+		case !ok:
+			campos = append(campos, apierror.Field("ano_ingreso", "format", "field.year_format"))
+		case int(ano) > hoy.Year():
+			campos = append(campos, apierror.Field("ano_ingreso", "future", "field.not_future"))
+		case ano < AnoIngresoMin:
+			campos = append(campos, apierror.Field("ano_ingreso", "min", "field.min"))
+		default:
+			apertura, err := anoApertura(db, idCarrera)
+			if err != nil {
+				return Datos{}, err
+			}
+			if apertura != nil && ano < *apertura {
+				campos = append(campos, apierror.Field("ano_ingreso", "before_carrera", "field.before_carrera", "year", *apertura))
+			} else {
+				datos.Ano_ingreso = &ano
+			}
+		}
+	}
+
+	if len(campos) > 0 {
+		return Datos{}, apierror.InvalidRequest.WithFields(campos...)
+	}
+	return datos, nil
+}
+
+// anoApertura devuelve el año de apertura de la carrera, o nil si no se conoce
+func anoApertura(db *gorm.DB, idCarrera uint) (*int16, error) {
+	if idCarrera == 0 {
+		return nil, nil
+	}
+	var carrera models.Carrera
+	if err := db.Select("ano_apertura").Limit(1).Find(&carrera, idCarrera).Error; err != nil {
+		return nil, apierror.DatabaseError.Wrap(err)
+	}
+	return carrera.Ano_apertura, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// first busca una fila y traduce la ausencia a ErrNotFound
+func first(db *gorm.DB, dest interface{}, query string, args ...interface{}) error {
+	result := db.Where(query, args...).Limit(1).Find(dest)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// updated traduce una actualización sin filas afectadas a ErrNotFound
+func updated(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func created(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+type gormUsuarios struct {
+	db *gorm.DB
+}
+
+// NewGormUsuarios crea el repositorio de estudiantes sobre la base de datos
+func NewGormUsuarios(db *gorm.DB) UsuarioRepository {
+	return &gormUsuarios{db: db}
+}
+
+func (r *gormUsuarios) WithTx(tx *gorm.DB) UsuarioRepository {
+	return &gormUsuarios{db: tx}
+}
+
+func (r *gormUsuarios) FindByCorreo(ctx context.Context, correo string) (*models.Usuario, error) {
+	var usuario models.Usuario
+	if err := first(r.db.WithContext(ctx), &usuario, "correo = ?", correo); err != nil {
+		return nil, err
+	}
+	return &usuario, nil
+}
+
+func (r *gormUsuarios) FindByFirebaseUID(ctx context.Context, uid string) (*models.Usuario, error) {
+	var usuario models.Usuario
+	if err := first(r.db.WithContext(ctx), &usuario, "firebase_usuario = ?", uid); err != nil {
+		return nil, err
+	}
+	return &usuario, nil
+}
+
+func (r *gormUsuarios) Create(ctx context.Context, usuario *models.Usuario) error {
+	return created(r.db.WithContext(ctx).Create(usuario).Error)
+}
+
+func (r *gormUsuarios) UpdatePerfil(ctx context.Context, uid string, perfil PerfilUsuario) error {
+	cambios := map[string]interface{}{"perfil_completado": true}
+	if perfil.Fecha_nacimiento != nil {
+		cambios["fecha_nacimiento"] = *perfil.Fecha_nacimiento
+	}
+	if perfil.Ano_ingreso != nil {
+		cambios["ano_ingreso"] = *perfil.Ano_ingreso
+	}
+	if perfil.Id_carrera != nil {
+		cambios["id_carrera"] = *perfil.Id_carrera
+	}
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario{}).Where("firebase_usuario = ?", uid).Updates(cambios))
+}
+
+func (r *gormUsuarios) SetFotoPerfil(ctx context.Context, uid, url string) error {
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario{}).Where("firebase_usuario = ?", uid).Update("foto_perfil", url))
+}
+
+func (r *gormUsuarios) SetIdioma(ctx context.Context, uid, idioma string) error {
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario{}).Where("firebase_usuario = ?", uid).Update("idioma", idioma))
+}
+
+func (r *gormUsuarios) SetSuspendido(ctx context.Context, uid string, suspendido bool) error {
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario{}).Where("firebase_usuario = ?", uid).Update("suspendido", suspendido))
+}
+
+func (r *gormUsuarios) MarkEmailVerified(ctx context.Context, correo string) error {
+	return r.db.WithContext(ctx).Model(&models.Usuario{}).Where("correo = ?", correo).Update("id_estado_usuario", true).Error
+}
+
+type gormEmpresas struct {
+	db *gorm.DB
+}
+
+// NewGormEmpresas crea el repositorio de empresas sobre la base de datos
+func NewGormEmpresas(db *gorm.DB) EmpresaRepository {
+	return &gormEmpresas{db: db}
+}
+
+func (r *gormEmpresas) WithTx(tx *gorm.DB) EmpresaRepository {
+	return &gormEmpresas{db: tx}
+}
+
+func (r *gormEmpresas) FindByCorreo(ctx context.Context, correo string) (*models.Usuario_empresa, error) {
+	var empresa models.Usuario_empresa
+	if err := first(r.db.WithContext(ctx), &empresa, "correo_empresa = ?", correo); err != nil {
+		return nil, err
+	}
+	return &empresa, nil
+}
+
+func (r *gormEmpresas) FindByFirebaseUID(ctx context.Context, uid string) (*models.Usuario_empresa, error) {
+	var empresa models.Usuario_empresa
+	if err := first(r.db.WithContext(ctx), &empresa, "firebase_usuario_empresa = ?", uid); err != nil {
+		return nil, err
+	}
+	return &empresa, nil
+}
+
+func (r *gormEmpresas) Create(ctx context.Context, empresa *models.Usuario_empresa) error {
+	return created(r.db.WithContext(ctx).Create(empresa).Error)
+}
+
+func (r *gormEmpresas) UpdatePerfil(ctx context.Context, uid string, perfil PerfilEmpresa) error {
+	// Updates con struct omite los campos vacíos, igual que el formulario de perfil
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario_empresa{}).Where("firebase_usuario_empresa = ?", uid).
+		Updates(models.Usuario_empresa{
+			Sector:            perfil.Sector,
+			Descripcion:       perfil.Descripcion,
+			Direccion:         perfil.Direccion,
+			Persona_contacto:  perfil.Persona_contacto,
+			Correo_contacto:   perfil.Correo_contacto,
+			Telefono_contacto: perfil.Telefono_contacto,
+			Perfil_Completado: true,
+		}))
+}
+
+func (r *gormEmpresas) SetIdioma(ctx context.Context, uid, idioma string) error {
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario_empresa{}).Where("firebase_usuario_empresa = ?", uid).Update("idioma", idioma))
+}
+
+func (r *gormEmpresas) SetSuspendido(ctx context.Context, uid string, suspendido bool) error {
+	return updated(r.db.WithContext(ctx).Model(&models.Usuario_empresa{}).Where("firebase_usuario_empresa = ?", uid).Update("suspendido", suspendido))
+}
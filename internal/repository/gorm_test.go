@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"login/internal/models"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newTestDB abre una base SQLite en memoria con el esquema de usuarios. TranslateError es
+// necesario para que las violaciones de unicidad lleguen como gorm.ErrDuplicatedKey, igual que en PostgreSQL
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		TranslateError: true,
+		Logger:         gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cada conexión a :memory: es una base distinta; con una sola todas las consultas ven el mismo esquema
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&models.Usuario{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// seedUsuario crea un estudiante de prueba y lo devuelve
+func seedUsuario(t *testing.T, repo UsuarioRepository, uid, correo string) *models.Usuario {
+	t.Helper()
+	usuario := &models.Usuario{Firebase_usuario: uid, Correo: correo, Nombres: "Ana", Apellidos: "Pérez", Rol: "estudiante"}
+	if err := repo.Create(context.Background(), usuario); err != nil {
+		t.Fatal(err)
+	}
+	return usuario
+}
+
+func TestGormUsuariosFind(t *testing.T) {
+	repo := NewGormUsuarios(newTestDB(t))
+	seedUsuario(t, repo, "uid-ana", "ana@alumnos.utalca.cl")
+
+	tests := []struct {
+		name    string
+		find    func(ctx context.Context) (*models.Usuario, error)
+		wantErr error
+	}{
+		{
+			name: "por correo existente",
+			find: func(ctx context.Context) (*models.Usuario, error) {
+				return repo.FindByCorreo(ctx, "ana@alumnos.utalca.cl")
+			},
+		},
+		{
+			name: "por correo inexistente",
+			find: func(ctx context.Context) (*models.Usuario, error) {
+				return repo.FindByCorreo(ctx, "nadie@alumnos.utalca.cl")
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "por UID existente",
+			find: func(ctx context.Context) (*models.Usuario, error) {
+				return repo.FindByFirebaseUID(ctx, "uid-ana")
+			},
+		},
+		{
+			name: "por UID inexistente",
+			find: func(ctx context.Context) (*models.Usuario, error) {
+				return repo.FindByFirebaseUID(ctx, "uid-nadie")
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usuario, err := tt.find(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if usuario != nil {
+					t.Errorf("se esperaba nil junto al error, se obtuvo %+v", usuario)
+				}
+				return
+			}
+			if usuario.Firebase_usuario != "uid-ana" || usuario.Correo != "ana@alumnos.utalca.cl" {
+				t.Errorf("usuario = %+v, se esperaba el de uid-ana", usuario)
+			}
+		})
+	}
+}
+
+func TestGormUsuariosCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		uid     string
+		correo  string
+		wantErr error
+	}{
+		{name: "UID nuevo", uid: "uid-luis", correo: "luis@alumnos.utalca.cl"},
+		{name: "UID repetido", uid: "uid-ana", correo: "otra@alumnos.utalca.cl", wantErr: ErrDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewGormUsuarios(newTestDB(t))
+			seedUsuario(t, repo, "uid-ana", "ana@alumnos.utalca.cl")
+
+			usuario := &models.Usuario{Firebase_usuario: tt.uid, Correo: tt.correo}
+			err := repo.Create(context.Background(), usuario)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && usuario.Id == 0 {
+				t.Error("Create debe asignar el Id generado")
+			}
+		})
+	}
+}
+
+func TestGormUsuariosUpdate(t *testing.T) {
+	nacimiento := models.NuevaFecha(2001, 3, 15)
+	ingreso := int16(2020)
+
+	tests := []struct {
+		name    string
+		uid     string
+		update  func(ctx context.Context, repo UsuarioRepository, uid string) error
+		check   func(t *testing.T, u *models.Usuario)
+		wantErr error
+	}{
+		{
+			name: "UpdatePerfil guarda los campos y completa el perfil",
+			uid:  "uid-ana",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.UpdatePerfil(ctx, uid, PerfilUsuario{Fecha_nacimiento: &nacimiento, Ano_ingreso: &ingreso})
+			},
+			check: func(t *testing.T, u *models.Usuario) {
+				if !u.PerfilCompletado {
+					t.Error("el perfil debe quedar completado")
+				}
+				if u.Fecha_nacimiento == nil || !u.Fecha_nacimiento.Equal(nacimiento.Time) {
+					t.Errorf("Fecha_nacimiento = %v, se esperaba %v", u.Fecha_nacimiento, nacimiento)
+				}
+				if u.Ano_ingreso == nil || *u.Ano_ingreso != ingreso {
+					t.Errorf("Ano_ingreso = %v, se esperaba %d", u.Ano_ingreso, ingreso)
+				}
+				if u.Id_carrera != nil {
+					t.Errorf("Id_carrera = %d, los campos nil no deben modificarse", *u.Id_carrera)
+				}
+			},
+		},
+		{
+			name: "SetFotoPerfil",
+			uid:  "uid-ana",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetFotoPerfil(ctx, uid, "https://storage.example/ana.png")
+			},
+			check: func(t *testing.T, u *models.Usuario) {
+				if u.Foto_perfil != "https://storage.example/ana.png" {
+					t.Errorf("Foto_perfil = %q", u.Foto_perfil)
+				}
+			},
+		},
+		{
+			name: "SetIdioma",
+			uid:  "uid-ana",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetIdioma(ctx, uid, "en")
+			},
+			check: func(t *testing.T, u *models.Usuario) {
+				if u.Idioma != "en" {
+					t.Errorf("Idioma = %q, se esperaba en", u.Idioma)
+				}
+			},
+		},
+		{
+			name: "SetSuspendido",
+			uid:  "uid-ana",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetSuspendido(ctx, uid, true)
+			},
+			check: func(t *testing.T, u *models.Usuario) {
+				if !u.Suspendido {
+					t.Error("la cuenta debe quedar suspendida")
+				}
+			},
+		},
+		{
+			name: "UpdatePerfil de un UID inexistente",
+			uid:  "uid-nadie",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.UpdatePerfil(ctx, uid, PerfilUsuario{Ano_ingreso: &ingreso})
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "SetFotoPerfil de un UID inexistente",
+			uid:  "uid-nadie",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetFotoPerfil(ctx, uid, "https://storage.example/x.png")
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "SetIdioma de un UID inexistente",
+			uid:  "uid-nadie",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetIdioma(ctx, uid, "en")
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "SetSuspendido de un UID inexistente",
+			uid:  "uid-nadie",
+			update: func(ctx context.Context, repo UsuarioRepository, uid string) error {
+				return repo.SetSuspendido(ctx, uid, true)
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewGormUsuarios(newTestDB(t))
+			seedUsuario(t, repo, "uid-ana", "ana@alumnos.utalca.cl")
+
+			err := tt.update(ctx, repo, tt.uid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba %v", err, tt.wantErr)
+			}
+			if tt.check == nil {
+				return
+			}
+			usuario, err := repo.FindByFirebaseUID(ctx, tt.uid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, usuario)
+		})
+	}
+}
+
+func TestGormUsuariosMarkEmailVerified(t *testing.T) {
+	tests := []struct {
+		name       string
+		correo     string
+		wantActivo bool
+	}{
+		{name: "activa la cuenta con el correo", correo: "ana@alumnos.utalca.cl", wantActivo: true},
+		{name: "un correo sin cuenta no es un error", correo: "nadie@alumnos.utalca.cl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewGormUsuarios(newTestDB(t))
+			seedUsuario(t, repo, "uid-ana", "ana@alumnos.utalca.cl")
+
+			if err := repo.MarkEmailVerified(ctx, tt.correo); err != nil {
+				t.Fatalf("MarkEmailVerified: %v", err)
+			}
+			usuario, err := repo.FindByFirebaseUID(ctx, "uid-ana")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if usuario.Id_estado_usuario != tt.wantActivo {
+				t.Errorf("Id_estado_usuario = %v, se esperaba %v", usuario.Id_estado_usuario, tt.wantActivo)
+			}
+		})
+	}
+}
+
+func TestGormUsuariosWithTx(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := NewGormUsuarios(db)
+
+	errAbortar := errors.New("abortar")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		seedUsuario(t, repo.WithTx(tx), "uid-ana", "ana@alumnos.utalca.cl")
+		return errAbortar
+	})
+	if !errors.Is(err, errAbortar) {
+		t.Fatalf("error = %v, se esperaba %v", err, errAbortar)
+	}
+
+	if _, err := repo.FindByFirebaseUID(ctx, "uid-ana"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, el rollback debe descartar lo creado dentro de la transacción", err)
+	}
+}
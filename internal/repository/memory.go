@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MemoryUsuarios guarda los estudiantes en memoria, para pruebas y desarrollo sin base de datos.
+// WithTx devuelve el mismo repositorio: los cambios no se revierten con la transacción
+type MemoryUsuarios struct {
+	mu       sync.Mutex
+	usuarios []models.Usuario
+}
+
+// NewMemoryUsuarios crea un repositorio de estudiantes vacío en memoria
+func NewMemoryUsuarios() *MemoryUsuarios {
+	return &MemoryUsuarios{}
+}
+
+func (r *MemoryUsuarios) WithTx(*gorm.DB) UsuarioRepository {
+	return r
+}
+
+func (r *MemoryUsuarios) find(match func(*models.Usuario) bool) (*models.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.usuarios {
+		if match(&r.usuarios[i]) {
+			usuario := r.usuarios[i]
+			return &usuario, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// update aplica el cambio a la cuenta con el UID
+func (r *MemoryUsuarios) update(uid string, cambio func(*models.Usuario)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.usuarios {
+		if r.usuarios[i].Firebase_usuario == uid {
+			cambio(&r.usuarios[i])
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *MemoryUsuarios) FindByCorreo(_ context.Context, correo string) (*models.Usuario, error) {
+	return r.find(func(u *models.Usuario) bool { return u.Correo == correo })
+}
+
+func (r *MemoryUsuarios) FindByFirebaseUID(_ context.Context, uid string) (*models.Usuario, error) {
+	return r.find(func(u *models.Usuario) bool { return u.Firebase_usuario == uid })
+}
+
+func (r *MemoryUsuarios) Create(_ context.Context, usuario *models.Usuario) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existente := range r.usuarios {
+		if existente.Firebase_usuario == usuario.Firebase_usuario {
+			return ErrDuplicate
+		}
+	}
+	usuario.Id = uint(len(r.usuarios) + 1)
+	r.usuarios = append(r.usuarios, *usuario)
+	return nil
+}
+
+func (r *MemoryUsuarios) UpdatePerfil(_ context.Context, uid string, perfil PerfilUsuario) error {
+	return r.update(uid, func(u *models.Usuario) {
+		if perfil.Fecha_nacimiento != nil {
+			u.Fecha_nacimiento = perfil.Fecha_nacimiento
+		}
+		if perfil.Ano_ingreso != nil {
+			u.Ano_ingreso = perfil.Ano_ingreso
+		}
+		if perfil.Id_carrera != nil {
+			u.Id_carrera = perfil.Id_carrera
+		}
+		u.PerfilCompletado = true
+	})
+}
+
+func (r *MemoryUsuarios) SetFotoPerfil(_ context.Context, uid, url string) error {
+	return r.update(uid, func(u *models.Usuario) { u.Foto_perfil = url })
+}
+
+func (r *MemoryUsuarios) SetIdioma(_ context.Context, uid, idioma string) error {
+	return r.update(uid, func(u *models.Usuario) { u.Idioma = idioma })
+}
+
+func (r *MemoryUsuarios) SetSuspendido(_ context.Context, uid string, suspendido bool) error {
+	return r.update(uid, func(u *models.Usuario) { u.Suspendido = suspendido })
+}
+
+func (r *MemoryUsuarios) MarkEmailVerified(_ context.Context, correo string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.usuarios {
+		if r.usuarios[i].Correo == correo {
+			r.usuarios[i].Id_estado_usuario = true
+		}
+	}
+	return nil
+}
+
+// MemoryEmpresas guarda las empresas en memoria, para pruebas y desarrollo sin base de datos.
+// WithTx devuelve el mismo repositorio: los cambios no se revierten con la transacción
+type MemoryEmpresas struct {
+	mu       sync.Mutex
+	empresas []models.Usuario_empresa
+}
+
+// NewMemoryEmpresas crea un repositorio de empresas vacío en memoria
+func NewMemoryEmpresas() *MemoryEmpresas {
+	return &MemoryEmpresas{}
+}
+
+func (r *MemoryEmpresas) WithTx(*gorm.DB) EmpresaRepository {
+	return r
+}
+
+func (r *MemoryEmpresas) find(match func(*models.Usuario_empresa) bool) (*models.Usuario_empresa, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.empresas {
+		if match(&r.empresas[i]) {
+			empresa := r.empresas[i]
+			return &empresa, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// update aplica el cambio a la cuenta con el UID
+func (r *MemoryEmpresas) update(uid string, cambio func(*models.Usuario_empresa)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.empresas {
+		if r.empresas[i].Firebase_usuario_empresa == uid {
+			cambio(&r.empresas[i])
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (r *MemoryEmpresas) FindByCorreo(_ context.Context, correo string) (*models.Usuario_empresa, error) {
+	return r.find(func(e *models.Usuario_empresa) bool { return e.Correo_empresa == correo })
+}
+
+func (r *MemoryEmpresas) FindByFirebaseUID(_ context.Context, uid string) (*models.Usuario_empresa, error) {
+	return r.find(func(e *models.Usuario_empresa) bool { return e.Firebase_usuario_empresa == uid })
+}
+
+func (r *MemoryEmpresas) Create(_ context.Context, empresa *models.Usuario_empresa) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existente := range r.empresas {
+		if existente.Firebase_usuario_empresa == empresa.Firebase_usuario_empresa {
+			return ErrDuplicate
+		}
+	}
+	empresa.Id_empresa = uint(len(r.empresas) + 1)
+	r.empresas = append(r.empresas, *empresa)
+	return nil
+}
+
+func (r *MemoryEmpresas) UpdatePerfil(_ context.Context, uid string, perfil PerfilEmpresa) error {
+	return r.update(uid, func(e *models.Usuario_empresa) {
+		for campo, valor := range map[*string]string{
+			&e.Sector:           perfil.Sector,
+			&e.Descripcion:      perfil.Descripcion,
+			&e.Direccion:        perfil.Direccion,
+			&e.Persona_contacto: perfil.Persona_contacto,
+			&e.Correo_contacto:  perfil.Correo_contacto,
+		} {
+			if valor != "" {
+				*campo = valor
+			}
+		}
+		if perfil.Telefono_contacto != 0 {
+			e.Telefono_contacto = perfil.Telefono_contacto
+		}
+		e.Perfil_Completado = true
+	})
+}
+
+func (r *MemoryEmpresas) SetIdioma(_ context.Context, uid, idioma string) error {
+	return r.update(uid, func(e *models.Usuario_empresa) { e.Idioma = idioma })
+}
+
+func (r *MemoryEmpresas) SetSuspendido(_ context.Context, uid string, suspendido bool) error {
+	return r.update(uid, func(e *models.Usuario_empresa) { e.Suspendido = suspendido })
+}
+
+// Comprobar en compilación que las implementaciones cumplen las interfaces
+var (
+	_ UsuarioRepository = (*MemoryUsuarios)(nil)
+	_ EmpresaRepository = (*MemoryEmpresas)(nil)
+)
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"login/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound indica que no existe la cuenta buscada
+	ErrNotFound = errors.New("cuenta no encontrada")
+	// ErrDuplicate indica que ya existe una cuenta con el mismo UID de Firebase
+	ErrDuplicate = errors.New("cuenta duplicada")
+)
+
+// PerfilUsuario son los datos que el estudiante completa en su perfil. Los campos nil no se modifican
+type PerfilUsuario struct {
+	Fecha_nacimiento *models.Fecha
+	Ano_ingreso      *int16
+	Id_carrera       *uint
+}
+
+// PerfilEmpresa son los datos que la empresa completa en su perfil. Los campos vacíos no se modifican
+type PerfilEmpresa struct {
+	Sector            string
+	Descripcion       string
+	Direccion         string
+	Persona_contacto  string
+	Correo_contacto   string
+	Telefono_contacto int
+}
+
+// UsuarioRepository accede a las cuentas de estudiantes
+type UsuarioRepository interface {
+	// WithTx devuelve un repositorio que opera dentro de la transacción indicada
+	WithTx(tx *gorm.DB) UsuarioRepository
+
+	FindByCorreo(ctx context.Context, correo string) (*models.Usuario, error)
+	FindByFirebaseUID(ctx context.Context, uid string) (*models.Usuario, error)
+	Create(ctx context.Context, usuario *models.Usuario) error
+
+	// UpdatePerfil guarda los datos del perfil y lo marca como completado
+	UpdatePerfil(ctx context.Context, uid string, perfil PerfilUsuario) error
+	SetFotoPerfil(ctx context.Context, uid, url string) error
+	SetIdioma(ctx context.Context, uid, idioma string) error
+	SetSuspendido(ctx context.Context, uid string, suspendido bool) error
+	// MarkEmailVerified activa las cuentas con el correo; no falla si no hay ninguna
+	MarkEmailVerified(ctx context.Context, correo string) error
+}
+
+// EmpresaRepository accede a las cuentas de empresas
+type EmpresaRepository interface {
+	// WithTx devuelve un repositorio que opera dentro de la transacción indicada
+	WithTx(tx *gorm.DB) EmpresaRepository
+
+	FindByCorreo(ctx context.Context, correo string) (*models.Usuario_empresa, error)
+	FindByFirebaseUID(ctx context.Context, uid string) (*models.Usuario_empresa, error)
+	Create(ctx context.Context, empresa *models.Usuario_empresa) error
+
+	// UpdatePerfil guarda los datos del perfil y lo marca como completado
+	UpdatePerfil(ctx context.Context, uid string, perfil PerfilEmpresa) error
+	SetIdioma(ctx context.Context, uid, idioma string) error
+	SetSuspendido(ctx context.Context, uid string, suspendido bool) error
+}
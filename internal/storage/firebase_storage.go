@@ -4,59 +4,67 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"net/url"
+	"time"
 
+	"login/internal/metrics"
+	"login/internal/tracing"
+
+	gcs "cloud.google.com/go/storage"
 	firebase "firebase.google.com/go"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/option"
 )
 
-// InitFirebaseStorage inicializa el cliente de Firebase Storage
-func InitFirebaseStorage() (*firebase.App, error) {
-	// Cargar el archivo de credenciales de Firebase (serviceAccountKey.json)
-	sa := option.WithCredentialsFile("config/serviceAccountKey.json") // Ruta al archivo de claves de servicio
-	app, err := firebase.NewApp(context.Background(), nil, sa)
-	if err != nil {
-		return nil, fmt.Errorf("error inicializando Firebase: %v", err)
-	}
-	return app, nil
+// FirebaseStorage implementa Storage sobre un bucket de Firebase Storage
+type FirebaseStorage struct {
+	bucket     *gcs.BucketHandle
+	bucketName string
 }
 
-// UploadFileToFirebase sube un archivo a Firebase Storage y devuelve la URL pública
-func UploadFileToFirebase(file *multipart.FileHeader, bucketName string) (string, error) {
-	// Inicializar Firebase Storage
-	app, err := InitFirebaseStorage()
+// NewFirebaseStorage inicializa el cliente de Firebase Storage para el bucket indicado
+func NewFirebaseStorage(ctx context.Context, credentialsFile, bucketName string) (*FirebaseStorage, error) {
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(credentialsFile))
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("error inicializando Firebase: %v", err)
 	}
 
-	ctx := context.Background()
 	client, err := app.Storage(ctx)
 	if err != nil {
-		return "", fmt.Errorf("error obteniendo cliente de storage: %v", err)
+		return nil, fmt.Errorf("error obteniendo cliente de storage: %v", err)
 	}
 
 	bucket, err := client.Bucket(bucketName)
 	if err != nil {
-		return "", fmt.Errorf("error obteniendo bucket: %v", err)
+		return nil, fmt.Errorf("error obteniendo bucket: %v", err)
 	}
+	return &FirebaseStorage{bucket: bucket, bucketName: bucketName}, nil
+}
 
-	// Abrir el archivo para subir
-	src, err := file.Open()
-	if err != nil {
-		return "", fmt.Errorf("error abriendo archivo: %v", err)
-	}
-	defer src.Close()
+// Upload sube el archivo al bucket y devuelve su URL pública
+func (s *FirebaseStorage) Upload(ctx context.Context, name, contentType string, r io.Reader) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "storage.upload",
+		attribute.String("storage.bucket", s.bucketName), attribute.String("storage.object", name))
+	defer func(start time.Time) {
+		tracing.End(span, err)
+		metrics.ObserveDependency(metrics.DependencyStorage, "upload", start, err)
+	}(time.Now())
 
-	// Crear el writer para subir el archivo a Firebase
-	wc := bucket.Object(file.Filename).NewWriter(ctx)
-	if _, err := io.Copy(wc, src); err != nil {
+	wc := s.bucket.Object(name).NewWriter(ctx)
+	wc.ContentType = contentType
+	if _, err := io.Copy(wc, r); err != nil {
+		wc.Close()
 		return "", fmt.Errorf("error subiendo archivo a Firebase: %v", err)
 	}
 	if err := wc.Close(); err != nil {
 		return "", fmt.Errorf("error cerrando el writer: %v", err)
 	}
 
-	// Retornar la URL pública del archivo subido
-	url := fmt.Sprintf("https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media", bucketName, file.Filename)
-	return url, nil
+	return fmt.Sprintf("https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media", s.bucketName, url.PathEscape(name)), nil
+}
+
+// Ping verifica que el bucket exista y sea accesible con las credenciales
+func (s *FirebaseStorage) Ping(ctx context.Context) error {
+	_, err := s.bucket.Attrs(ctx)
+	return err
 }
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// MemoryStorage guarda los archivos en memoria, útil en pruebas y desarrollo local
+type MemoryStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemoryStorage crea un almacenamiento vacío
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{files: map[string][]byte{}}
+}
+
+// Upload guarda el contenido y devuelve una URL con el esquema memory://
+func (s *MemoryStorage) Upload(_ context.Context, name, _ string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = data
+	return "memory://" + name, nil
+}
+
+// File devuelve el contenido guardado con el nombre indicado
+func (s *MemoryStorage) File(name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[name]
+	return data, ok
+}
+
+var (
+	_ Storage = (*FirebaseStorage)(nil)
+	_ Storage = (*MemoryStorage)(nil)
+)
@@ -0,0 +1,11 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage guarda archivos y devuelve su URL pública
+type Storage interface {
+	Upload(ctx context.Context, name, contentType string, r io.Reader) (string, error)
+}
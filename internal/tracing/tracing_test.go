@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndOcultaDatosDelError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer(instrumentationName).Start(context.Background(), "firebase.create_user")
+	End(span, errors.New("EMAIL_EXISTS: ana.perez@alumnos.utalca.cl idToken=eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJ1aWQifQ.firma"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, se esperaba 1", len(spans))
+	}
+	got := spans[0]
+	if got.Status().Code != codes.Error {
+		t.Errorf("status = %v, se esperaba Error", got.Status().Code)
+	}
+
+	textos := []string{got.Status().Description}
+	for _, event := range got.Events() {
+		for _, attr := range event.Attributes {
+			textos = append(textos, attr.Value.Emit())
+		}
+	}
+	for _, texto := range textos {
+		if strings.Contains(texto, "ana.perez") || strings.Contains(texto, "eyJhbGci") {
+			t.Errorf("el span exporta datos sin ocultar: %q", texto)
+		}
+	}
+	if !strings.Contains(got.Status().Description, "EMAIL_EXISTS") {
+		t.Errorf("status = %q, debe conservar el resto del mensaje", got.Status().Description)
+	}
+}
@@ -1,14 +1,35 @@
 package upload
 
 import (
-	"login/internal/database"
-	"login/internal/models"
-	"login/internal/storage"
+	"errors"
 	"net/http"
 
+	"login/internal/apierror"
+	"login/internal/i18n"
+	"login/internal/repository"
+	"login/internal/storage"
+
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// MaxImageBytes es el tamaño máximo de una imagen de perfil
+	MaxImageBytes = 10 << 20
+	// maxFormOverhead es el margen para los encabezados y separadores del formulario multipart
+	maxFormOverhead = 64 << 10
+)
+
+// Handler sube las imágenes al almacenamiento y guarda la URL en el perfil del estudiante
+type Handler struct {
+	usuarios repository.UsuarioRepository
+	storage  storage.Storage
+}
+
+// NewHandler crea el handler de subida de imágenes
+func NewHandler(usuarios repository.UsuarioRepository, storage storage.Storage) *Handler {
+	return &Handler{usuarios: usuarios, storage: storage}
+}
+
 // UploadImageHandler maneja la subida de imágenes de perfil y actualiza el perfil del usuario
 // @Summary Subir una imagen de perfil
 // @Description Sube una imagen a Firebase Storage y actualiza el campo de foto de perfil del usuario autenticado
@@ -18,42 +39,63 @@ import (
 // @Param Authorization header string true "Bearer token"
 // @Param file formData file true "Imagen a subir"
 // @Success 200 {object} map[string]string "URL de la imagen subida y mensaje de éxito"
-// @Failure 400 {object} map[string]string "Error en la solicitud"
-// @Failure 401 {object} map[string]string "Usuario no autenticado"
-// @Failure 500 {object} map[string]string "Error al subir la imagen"
+// @Failure 400 {object} apierror.ErrorResponse "Error en la solicitud"
+// @Failure 401 {object} apierror.ErrorResponse "Usuario no autenticado"
+// @Failure 413 {object} apierror.ErrorResponse "Archivo demasiado grande (máximo 10 MiB)"
+// @Failure 500 {object} apierror.ErrorResponse "Error al subir la imagen"
 // @Router /upload-image [post]
-func UploadImageHandler(c *gin.Context) {
+func (h *Handler) UploadImageHandler(c *gin.Context) {
 	uid, exists := c.Get("uid")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no autenticado"})
+		apierror.Respond(c, apierror.Unauthenticated)
 		return
 	}
 
+	// Limitar el cuerpo aquí y no solo en el middleware de idempotencia, que solo lo lee
+	// cuando la solicitud trae Idempotency-Key
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImageBytes+maxFormOverhead)
+
 	// Obtener el archivo del formulario
 	file, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		apierror.Respond(c, apierror.RequestTooLarge)
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, apierror.FileMissing.Wrap(err))
+		return
+	}
+	if file.Size > MaxImageBytes {
+		apierror.Respond(c, apierror.RequestTooLarge)
+		return
+	}
+
+	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No se ha proporcionado un archivo"})
+		apierror.Respond(c, apierror.UploadFailed.Wrap(err))
 		return
 	}
+	defer src.Close()
 
-	// Subir la imagen a Firebase Storage
-	url, err := storage.UploadFileToFirebase(file, "ulink-sprint-1.appspot.com") // Reemplaza con tu bucket
+	// Subir la imagen al almacenamiento
+	url, err := h.storage.Upload(c.Request.Context(), file.Filename, file.Header.Get("Content-Type"), src)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al subir la imagen", "details": err.Error()})
+		apierror.Respond(c, apierror.UploadFailed.Wrap(err))
 		return
 	}
 
 	// Actualizar el campo FotoPerfil en la base de datos
-	var usuario models.Usuario
-	result := database.DB.Model(&usuario).Where("firebase_usuario = ?", uid).Update("foto_perfil", url)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar la foto de perfil en la base de datos"})
+	// Las empresas no tienen foto de perfil en la base de datos, por lo que solo reciben la URL
+	err = h.usuarios.SetFotoPerfil(c.Request.Context(), uid.(string), url)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		apierror.Respond(c, apierror.ProfilePhotoFailed.Wrap(err))
 		return
 	}
 
 	// Responder con la URL de la imagen subida
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Imagen subida y foto de perfil actualizada correctamente",
+		"message": i18n.T(i18n.FromContext(c), "message.image_uploaded"),
 		"url":     url,
 	})
 }
@@ -0,0 +1,74 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"login/internal/repository"
+	"login/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartImage arma un formulario con un archivo del tamaño indicado en el campo file
+func multipartImage(t *testing.T, size int) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", "foto.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(bytes.Repeat([]byte{0x89}, size)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &body, w.FormDataContentType()
+}
+
+// TestUploadImageSinIdempotencyKey sube imágenes sin el encabezado Idempotency-Key, por lo que
+// el límite de tamaño depende solo del handler
+func TestUploadImageSinIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		wantCode int
+		wantErr  string
+	}{
+		{name: "imagen pequeña", size: 1 << 10, wantCode: http.StatusOK},
+		{name: "imagen en el límite", size: MaxImageBytes, wantCode: http.StatusOK},
+		{name: "imagen sobre el límite", size: MaxImageBytes + 1, wantCode: http.StatusRequestEntityTooLarge, wantErr: "request_too_large"},
+		{name: "cuerpo muy sobre el límite", size: 3 * MaxImageBytes, wantCode: http.StatusRequestEntityTooLarge, wantErr: "request_too_large"},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(repository.NewMemoryUsuarios(), storage.NewMemoryStorage())
+			router := gin.New()
+			router.POST("/upload-image", func(c *gin.Context) { c.Set("uid", "uid-1") }, h.UploadImageHandler)
+
+			body, contentType := multipartImage(t, tt.size)
+			req := httptest.NewRequest(http.MethodPost, "/upload-image", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, se esperaba %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantErr != "" {
+				var resp struct{ Code string }
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Code != tt.wantErr {
+					t.Errorf("code = %q, se esperaba %q", resp.Code, tt.wantErr)
+				}
+			}
+		})
+	}
+}
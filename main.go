@@ -1,12 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
-	"login/api"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"login/internal/app"
 	"login/internal/auth"
 	"login/internal/database"
-	"login/internal/models"
+	"login/internal/logging"
+	"login/internal/mailer"
+	"login/internal/migrations"
 	"login/internal/storage"
+	"login/internal/tracing"
 	"login/pkg/config"
 
 	_ "login/docs" // Importar la documentación generada
@@ -16,52 +29,94 @@ import (
 )
 
 func main() {
-	// Cargar las configuraciones desde el archivo .env
-	err := config.LoadConfig("config/app.env")
+	// Cargar la configuración: valores por defecto, config/app.env (opcional), entorno y flags
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Printf("Uso: %s [flags] [migrate up|down [pasos]|status]\n%s", os.Args[0], config.Usage())
+		return
+	}
 	if err != nil {
-		log.Fatalf("Error cargando configuración: %v", err)
+		log.Fatal(err)
 	}
 
+	// Los logs se escriben en JSON y ocultan correos, teléfonos, tokens y contraseñas
+	logging.Setup(cfg.Log)
+
 	// Inicializar la base de datos
-	err = database.InitDatabase()
+	db, err := database.Open(cfg.Database)
 	if err != nil {
-		log.Fatalf("Error inicializando la base de datos: %v", err)
+		fatal("Error inicializando la base de datos", "error", err)
 	}
 
-	// Realizar la migración de la tabla fuera de la transacción
-	if !database.DB.Migrator().HasTable(&models.Usuario{}) {
-		err = database.DB.AutoMigrate(&models.Usuario{})
+	// El comando migrate administra el esquema y termina sin iniciar el servidor
+	if len(args) > 0 && args[0] == "migrate" {
+		err = runMigrate(db, args[1:])
 		if err != nil {
-			log.Fatalf("Error al migrar modelos: %v", err)
+			fatal("Error en las migraciones", "error", err)
 		}
+		return
 	}
 
-	// Realizar la migración de la tabla fuera de la transacción
-	if !database.DB.Migrator().HasTable(&models.Usuario_empresa{}) {
-		err = database.DB.AutoMigrate(&models.Usuario_empresa{})
-		if err != nil {
-			log.Fatalf("Error al migrar modelos: %v", err)
-		}
+	// El servidor no migra el esquema; se niega a iniciar si falta aplicar alguna migración
+	pendientes, err := migrations.Pending(db)
+	if err != nil {
+		fatal("Error consultando las migraciones", "error", err)
+	}
+	if len(pendientes) > 0 {
+		fatal("Hay migraciones pendientes; ejecuta `migrate up` antes de iniciar el servidor", "pendientes", len(pendientes), "desde", pendientes[0])
 	}
 
+	ctx := context.Background()
+
+	// Configurar las trazas de OpenTelemetry
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		fatal("Error inicializando las trazas", "error", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Error("Error enviando las trazas pendientes", "error", err)
+		}
+	}()
+
 	// Inicializar Firebase
-	err = auth.InitFirebase()
+	identity, err := auth.NewFirebaseIdentity(ctx, cfg.Firebase.CredentialsFile, cfg.Firebase.APIKey)
 	if err != nil {
-		log.Fatalf("Error inicializando Firebase: %v", err)
+		fatal("Error inicializando Firebase", "error", err)
 	}
 
 	// Inicializar Firebase Storage
-	_, err = storage.InitFirebaseStorage()
+	files, err := storage.NewFirebaseStorage(ctx, cfg.Firebase.CredentialsFile, cfg.Firebase.StorageBucket)
+	if err != nil {
+		fatal("Error inicializando Firebase Storage", "error", err)
+	}
+
+	// Inicializar el backend de correo
+	mail, err := mailer.New(cfg.Mail)
 	if err != nil {
-		log.Fatalf("Error inicializando Firebase Storage: %v", err)
+		fatal("Error inicializando el mailer", "error", err)
 	}
 
+	application := app.New(cfg, app.Deps{DB: db, Identity: identity, Mailer: mail, Storage: files})
+
 	//Registrar rutas
-	router := api.SetupRoutes()
+	router := application.Router()
 
 	// Agregar la ruta de Swagger
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Iniciar el servidor
-	router.Run(":8080")
+	// Atender solicitudes hasta recibir SIGTERM (rollout de Kubernetes) o SIGINT
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	if err := application.Run(ctx); err != nil {
+		fatal("Error en el servidor HTTP", "error", err)
+	}
+}
+
+// fatal registra el error y termina el proceso
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"login/internal/migrations"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "uso: migrate up | migrate down [pasos] | migrate status"
+
+// runMigrate ejecuta los subcomandos de migración del esquema
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		aplicadas, err := migrations.Up(db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migraciones aplicadas\n", aplicadas)
+		return nil
+
+	case "down":
+		pasos := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("pasos inválidos %q; %s", args[1], migrateUsage)
+			}
+			pasos = n
+		}
+		revertidas, err := migrations.Down(db, pasos)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migraciones revertidas\n", revertidas)
+		return nil
+
+	case "status":
+		estados, err := migrations.StatusAll(db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSIÓN\tESTADO\tAPLICADA EN")
+		for _, estado := range estados {
+			texto, aplicadaEn := "pendiente", ""
+			if estado.Applied {
+				texto = "aplicada"
+				aplicadaEn = estado.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if estado.Unknown {
+				texto = "aplicada (desconocida)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", estado.Migration, texto, aplicadaEn)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("subcomando desconocido %q; %s", args[0], migrateUsage)
+	}
+}
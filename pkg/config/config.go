@@ -1,21 +1,515 @@
 package config
 
 import (
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-// Cargar las variables de entorno desde el archivo .env
-func LoadConfig(filePath string) error {
-	err := godotenv.Load(filePath)
+// DefaultEnvFile es el archivo .env opcional que se carga si existe
+const DefaultEnvFile = "config/app.env"
+
+// Config reúne la configuración con la que se construye la aplicación
+type Config struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+	Firebase FirebaseConfig
+	Mail     MailConfig
+	Tracing  TracingConfig
+	Log      LogConfig
+	// Verification es cómo se verifica el correo y qué se permite mientras no se verifica
+	Verification VerificationConfig
+	// Password es la política de contraseñas de los registros y cambios de contraseña
+	Password PasswordConfig
+	// IdempotencyTTL es el tiempo durante el cual se repite la respuesta de una Idempotency-Key
+	IdempotencyTTL time.Duration
+	// JWTSecret firma los tokens de verificación y de restablecimiento de contraseña
+	JWTSecret string
+	// BaseURL es la dirección pública de la API, usada en los enlaces de los correos
+	BaseURL string
+	// CORSOrigins son los orígenes de los frontends autorizados a llamar a la API
+	CORSOrigins []string
+}
+
+// ServerConfig son la dirección y los límites del servidor HTTP
+type ServerConfig struct {
+	Addr string
+	// MetricsAddr es la dirección interna de /metrics, separada para no publicarla en el ingress
+	MetricsAddr       string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// ShutdownTimeout es el tiempo máximo para terminar las solicitudes en curso al apagarse
+	ShutdownTimeout time.Duration
+}
+
+// DatabaseConfig son los datos de conexión a PostgreSQL
+type DatabaseConfig struct {
+	Host     string
+	User     string
+	Password string
+	Name     string
+	Port     int
+	SSLMode  string
+}
+
+// FirebaseConfig son las credenciales de Firebase y el bucket de Storage
+type FirebaseConfig struct {
+	CredentialsFile string
+	APIKey          string
+	StorageBucket   string
+}
+
+// MailConfig es el backend de correo y los datos del servidor SMTP
+type MailConfig struct {
+	Backend   string // smtp, console, file o memory
+	Host      string
+	Port      int
+	User      string
+	Password  string
+	TLS       string // starttls, tls o none
+	From      string
+	FromName  string
+	OutboxDir string // directorio del backend file
+}
+
+// TracingConfig es el exportador de trazas de OpenTelemetry
+type TracingConfig struct {
+	Exporter    string // none, stdout u otlp
+	Endpoint    string // URL del colector OTLP/HTTP
+	ServiceName string
+	SampleRatio float64 // fracción de trazas nuevas que se registran, de 0 a 1
+}
+
+// LogConfig es el nivel y el formato de los logs
+type LogConfig struct {
+	Level  string // debug, info, warn o error
+	Format string // json o text
+}
+
+// VerificationConfig es el método de verificación del correo y la política de inicio de sesión
+// de las cuentas sin verificar
+type VerificationConfig struct {
+	Method    string // link, code o both
+	Policy    string // strict, grace u off
+	GraceDays int    // días desde el registro en que se permite entrar sin verificar con la política grace
+}
+
+// PasswordConfig son los requisitos de las contraseñas
+type PasswordConfig struct {
+	MinLength          int
+	MaxLength          int // 0 no limita el largo
+	RequireUpper       bool
+	RequireLower       bool
+	RequireDigit       bool
+	RequireSymbol      bool
+	RejectPersonalInfo bool // rechaza contraseñas con el correo, nombres o apellidos
+	RejectBreached     bool // rechaza contraseñas comunes o filtradas
+}
+
+// ValidationError lista todos los ajustes faltantes o mal formados
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "configuración inválida:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// setting describe un ajuste: su variable de entorno, su flag, el valor por defecto y cómo
+// se guarda en Config
+type setting struct {
+	env      string
+	flag     string
+	usage    string
+	def      string
+	required bool
+	secret   bool
+	set      func(c *Config, v string) error
+}
+
+var settings = []setting{
+	{env: "HTTP_ADDR", flag: "addr", usage: "dirección en la que escucha el servidor HTTP", def: ":8080",
+		set: func(c *Config, v string) error {
+			if _, _, err := net.SplitHostPort(v); err != nil {
+				return fmt.Errorf("debe tener la forma host:puerto o :puerto")
+			}
+			c.Server.Addr = v
+			return nil
+		}},
+	{env: "METRICS_ADDR", flag: "metrics-addr", usage: "dirección interna en la que se publica /metrics", def: ":9090",
+		set: func(c *Config, v string) error {
+			if _, _, err := net.SplitHostPort(v); err != nil {
+				return fmt.Errorf("debe tener la forma host:puerto o :puerto")
+			}
+			c.Server.MetricsAddr = v
+			return nil
+		}},
+	{env: "HTTP_READ_TIMEOUT", flag: "read-timeout", usage: "tiempo máximo para leer una solicitud completa", def: "30s",
+		set: func(c *Config, v string) error { return parseDuration(&c.Server.ReadTimeout, v) }},
+	{env: "HTTP_READ_HEADER_TIMEOUT", flag: "read-header-timeout", usage: "tiempo máximo para leer los encabezados", def: "10s",
+		set: func(c *Config, v string) error { return parseDuration(&c.Server.ReadHeaderTimeout, v) }},
+	{env: "HTTP_WRITE_TIMEOUT", flag: "write-timeout", usage: "tiempo máximo para escribir la respuesta", def: "60s",
+		set: func(c *Config, v string) error { return parseDuration(&c.Server.WriteTimeout, v) }},
+	{env: "HTTP_IDLE_TIMEOUT", flag: "idle-timeout", usage: "tiempo máximo de una conexión keep-alive inactiva", def: "120s",
+		set: func(c *Config, v string) error { return parseDuration(&c.Server.IdleTimeout, v) }},
+	{env: "HTTP_MAX_HEADER_BYTES", flag: "max-header-bytes", usage: "tamaño máximo de los encabezados en bytes", def: "1048576",
+		set: func(c *Config, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 4096 {
+				return fmt.Errorf("debe ser un número de bytes mayor o igual a 4096")
+			}
+			c.Server.MaxHeaderBytes = n
+			return nil
+		}},
+	{env: "SHUTDOWN_TIMEOUT", flag: "shutdown-timeout", usage: "tiempo máximo para terminar las solicitudes en curso al apagarse", def: "25s",
+		set: func(c *Config, v string) error { return parseDuration(&c.Server.ShutdownTimeout, v) }},
+
+	{env: "SUPABASE_HOST", flag: "db-host", usage: "host de PostgreSQL", required: true,
+		set: func(c *Config, v string) error { c.Database.Host = v; return nil }},
+	{env: "SUPABASE_PORT", flag: "db-port", usage: "puerto de PostgreSQL", def: "5432",
+		set: func(c *Config, v string) error { return parsePort(&c.Database.Port, v) }},
+	{env: "SUPABASE_USER", flag: "db-user", usage: "usuario de PostgreSQL", required: true,
+		set: func(c *Config, v string) error { c.Database.User = v; return nil }},
+	{env: "SUPABASE_PASSWORD", flag: "db-password", usage: "contraseña de PostgreSQL", required: true, secret: true,
+		set: func(c *Config, v string) error { c.Database.Password = v; return nil }},
+	{env: "SUPABASE_DB", flag: "db-name", usage: "nombre de la base de datos", required: true,
+		set: func(c *Config, v string) error { c.Database.Name = v; return nil }},
+	{env: "DB_SSLMODE", flag: "db-sslmode", usage: "sslmode de PostgreSQL", def: "require",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Database.SSLMode, v, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+		}},
+
+	{env: "FIREBASE_CREDENTIALS_FILE", flag: "firebase-credentials", usage: "archivo de la cuenta de servicio de Firebase", def: "config/serviceAccountKey.json",
+		set: func(c *Config, v string) error {
+			c.Firebase.CredentialsFile = v
+			if _, err := os.Stat(v); err != nil {
+				return fmt.Errorf("no se puede leer el archivo %s", v)
+			}
+			return nil
+		}},
+	{env: "FIREBASE_API_KEY", flag: "firebase-api-key", usage: "API key web de Firebase para el inicio de sesión", required: true, secret: true,
+		set: func(c *Config, v string) error { c.Firebase.APIKey = v; return nil }},
+	{env: "FIREBASE_STORAGE_BUCKET", flag: "storage-bucket", usage: "bucket de Firebase Storage para las imágenes", def: "ulink-sprint-1.appspot.com",
+		set: func(c *Config, v string) error { c.Firebase.StorageBucket = v; return nil }},
+
+	{env: "JWT_SECRET_KEY", flag: "jwt-secret", usage: "clave que firma los tokens de verificación", required: true, secret: true,
+		set: func(c *Config, v string) error { c.JWTSecret = v; return nil }},
+	{env: "PUBLIC_BASE_URL", flag: "base-url", usage: "URL pública de la API usada en los enlaces de los correos", def: "https://api-ulink.tssw.info",
+		set: func(c *Config, v string) error {
+			u, err := absoluteURL(v)
+			c.BaseURL = strings.TrimSuffix(u, "/")
+			return err
+		}},
+	{env: "CORS_ALLOWED_ORIGINS", flag: "cors-origins", usage: "orígenes permitidos por CORS, separados por comas",
+		def: "http://localhost,https://practicas.tssw.info,https://descuentos.tssw.info,https://roomies.tssw.info,https://ulink.tssw.info",
+		set: func(c *Config, v string) error {
+			c.CORSOrigins = nil
+			for _, origin := range strings.Split(v, ",") {
+				origin = strings.TrimSpace(origin)
+				if origin == "" {
+					continue
+				}
+				if _, err := absoluteURL(origin); err != nil {
+					return fmt.Errorf("origen %q: %v", origin, err)
+				}
+				c.CORSOrigins = append(c.CORSOrigins, strings.TrimSuffix(origin, "/"))
+			}
+			if len(c.CORSOrigins) == 0 {
+				return fmt.Errorf("debe indicar al menos un origen")
+			}
+			return nil
+		}},
+
+	{env: "VERIFICATION_METHOD", flag: "verification-method", usage: "cómo se verifica el correo (link, code, both)", def: "link",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Verification.Method, strings.ToLower(v), "link", "code", "both")
+		}},
+	{env: "EMAIL_VERIFICATION_POLICY", flag: "email-verification-policy", usage: "inicio de sesión sin verificar el correo (strict, grace, off)", def: "grace",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Verification.Policy, strings.ToLower(v), "strict", "grace", "off")
+		}},
+	{env: "EMAIL_VERIFICATION_GRACE_DAYS", flag: "email-verification-grace-days", usage: "días para verificar el correo con la política grace", def: "7",
+		set: func(c *Config, v string) error {
+			days, err := strconv.Atoi(v)
+			if err != nil || days < 0 {
+				return fmt.Errorf("debe ser un número de días mayor o igual a 0")
+			}
+			c.Verification.GraceDays = days
+			return nil
+		}},
+
+	{env: "PASSWORD_MIN_LENGTH", flag: "password-min-length", usage: "largo mínimo de las contraseñas", def: "8",
+		set: func(c *Config, v string) error {
+			n, err := strconv.Atoi(v)
+			// Firebase rechaza las contraseñas de menos de 6 caracteres
+			if err != nil || n < 6 {
+				return fmt.Errorf("debe ser un número mayor o igual a 6")
+			}
+			c.Password.MinLength = n
+			return nil
+		}},
+	{env: "PASSWORD_MAX_LENGTH", flag: "password-max-length", usage: "largo máximo de las contraseñas, 0 sin límite", def: "128",
+		set: func(c *Config, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("debe ser un número mayor o igual a 0")
+			}
+			c.Password.MaxLength = n
+			return nil
+		}},
+	{env: "PASSWORD_REQUIRE_UPPER", flag: "password-require-upper", usage: "exige una letra mayúscula en las contraseñas", def: "true",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RequireUpper, v) }},
+	{env: "PASSWORD_REQUIRE_LOWER", flag: "password-require-lower", usage: "exige una letra minúscula en las contraseñas", def: "true",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RequireLower, v) }},
+	{env: "PASSWORD_REQUIRE_DIGIT", flag: "password-require-digit", usage: "exige un número en las contraseñas", def: "true",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RequireDigit, v) }},
+	{env: "PASSWORD_REQUIRE_SYMBOL", flag: "password-require-symbol", usage: "exige un símbolo en las contraseñas", def: "false",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RequireSymbol, v) }},
+	{env: "PASSWORD_REJECT_PERSONAL_INFO", flag: "password-reject-personal-info", usage: "rechaza contraseñas con el correo, nombres o apellidos", def: "true",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RejectPersonalInfo, v) }},
+	{env: "PASSWORD_REJECT_BREACHED", flag: "password-reject-breached", usage: "rechaza contraseñas comunes o filtradas", def: "true",
+		set: func(c *Config, v string) error { return parseBool(&c.Password.RejectBreached, v) }},
+
+	{env: "IDEMPOTENCY_TTL", flag: "idempotency-ttl", usage: "tiempo durante el cual se repite la respuesta de una Idempotency-Key", def: "24h",
+		set: func(c *Config, v string) error { return parseDuration(&c.IdempotencyTTL, v) }},
+
+	{env: "MAILER_BACKEND", flag: "mailer", usage: "backend de correo (smtp, console, file, memory)", def: "smtp",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Mail.Backend, strings.ToLower(v), "smtp", "console", "file", "memory")
+		}},
+	{env: "SMTP_HOST", flag: "smtp-host", usage: "servidor SMTP", def: "smtp.gmail.com",
+		set: func(c *Config, v string) error { c.Mail.Host = v; return nil }},
+	{env: "SMTP_PORT", flag: "smtp-port", usage: "puerto del servidor SMTP", def: "587",
+		set: func(c *Config, v string) error { return parsePort(&c.Mail.Port, v) }},
+	{env: "SMTP_USER", flag: "smtp-user", usage: "usuario SMTP",
+		set: func(c *Config, v string) error { c.Mail.User = v; return nil }},
+	{env: "SMTP_PASSWORD", flag: "smtp-password", usage: "contraseña SMTP", secret: true,
+		set: func(c *Config, v string) error { c.Mail.Password = v; return nil }},
+	{env: "SMTP_TLS", flag: "smtp-tls", usage: "cifrado SMTP (starttls, tls, none)", def: "starttls",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Mail.TLS, strings.ToLower(v), "starttls", "tls", "none")
+		}},
+	{env: "SMTP_FROM", flag: "smtp-from", usage: "dirección del remitente; por defecto SMTP_USER",
+		set: func(c *Config, v string) error { c.Mail.From = v; return nil }},
+	{env: "SMTP_FROM_NAME", flag: "smtp-from-name", usage: "nombre visible del remitente",
+		set: func(c *Config, v string) error { c.Mail.FromName = v; return nil }},
+	{env: "MAILER_OUTBOX_DIR", flag: "mailer-outbox-dir", usage: "directorio donde el backend file guarda los correos", def: "outbox",
+		set: func(c *Config, v string) error { c.Mail.OutboxDir = v; return nil }},
+
+	{env: "LOG_LEVEL", flag: "log-level", usage: "nivel mínimo de los logs (debug, info, warn, error)", def: "info",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Log.Level, strings.ToLower(v), "debug", "info", "warn", "error")
+		}},
+	{env: "LOG_FORMAT", flag: "log-format", usage: "formato de los logs (json, text)", def: "json",
+		set: func(c *Config, v string) error {
+			return oneOf(&c.Log.Format, strings.ToLower(v), "json", "text")
+		}},
+
+	{env: "OTEL_TRACES_EXPORTER", flag: "traces-exporter", usage: "exportador de trazas (none, stdout, otlp)", def: "none",
+		set: func(c *Config, v string) error {
+			if v == "console" {
+				v = "stdout"
+			}
+			return oneOf(&c.Tracing.Exporter, strings.ToLower(v), "none", "stdout", "otlp")
+		}},
+	{env: "OTEL_EXPORTER_OTLP_ENDPOINT", flag: "otlp-endpoint", usage: "URL del colector OTLP/HTTP", def: "http://localhost:4318",
+		set: func(c *Config, v string) error {
+			u, err := absoluteURL(v)
+			c.Tracing.Endpoint = u
+			return err
+		}},
+	{env: "OTEL_SERVICE_NAME", flag: "service-name", usage: "nombre del servicio en las trazas", def: "back-login",
+		set: func(c *Config, v string) error { c.Tracing.ServiceName = v; return nil }},
+	{env: "OTEL_TRACES_SAMPLER_ARG", flag: "traces-sample-ratio", usage: "fracción de trazas que se registran, de 0 a 1", def: "1",
+		set: func(c *Config, v string) error {
+			ratio, err := strconv.ParseFloat(v, 64)
+			if err != nil || ratio < 0 || ratio > 1 {
+				return fmt.Errorf("debe ser un número entre 0 y 1")
+			}
+			c.Tracing.SampleRatio = ratio
+			return nil
+		}},
+}
+
+// Defaults devuelve la configuración con los valores por defecto, sin leer el entorno ni
+// validar los ajustes obligatorios; útil para construir la aplicación en pruebas
+func Defaults() Config {
+	var cfg Config
+	for _, s := range settings {
+		if s.def != "" {
+			s.set(&cfg, s.def)
+		}
+	}
+	return cfg
+}
+
+// Load arma la configuración a partir de, en orden de prioridad creciente: los valores por
+// defecto, el archivo .env (opcional), las variables de entorno y los flags de la línea de
+// comandos. Devuelve los argumentos que quedan después de los flags, como el comando migrate.
+// Si hay problemas devuelve un *ValidationError con todos ellos
+func Load(args []string) (Config, []string, error) {
+	fset := flag.NewFlagSet("login", flag.ContinueOnError)
+	fset.SetOutput(io.Discard)
+	envFile := fset.String("config", DefaultEnvFile, "archivo .env con la configuración")
+	values := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		values[s.flag] = fset.String(s.flag, "", s.usage+" ("+s.env+")")
+	}
+	if err := fset.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return Config{}, nil, err
+		}
+		return Config{}, nil, &ValidationError{Problems: []string{err.Error()}}
+	}
+
+	given := map[string]bool{}
+	fset.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	var problems []string
+
+	// godotenv no reemplaza las variables que ya existen en el entorno. El archivo es opcional
+	// salvo que se indique explícitamente con -config
+	if err := godotenv.Load(*envFile); err != nil {
+		if given["config"] || !errors.Is(err, fs.ErrNotExist) {
+			problems = append(problems, fmt.Sprintf("archivo de configuración %s: %v", *envFile, err))
+		}
+	}
+
+	var cfg Config
+	for _, s := range settings {
+		v := s.def
+		if env, ok := os.LookupEnv(s.env); ok && env != "" {
+			v = env
+		}
+		if given[s.flag] {
+			v = *values[s.flag]
+		}
+
+		if v == "" {
+			if s.required {
+				problems = append(problems, fmt.Sprintf("falta %s (variable %s o flag -%s)", s.usage, s.env, s.flag))
+			}
+			continue
+		}
+		if err := s.set(&cfg, v); err != nil {
+			shown := v
+			if s.secret {
+				shown = "***"
+			}
+			problems = append(problems, fmt.Sprintf("%s=%q inválido: %v", s.env, shown, err))
+		}
+	}
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return cfg, fset.Args(), &ValidationError{Problems: problems}
+	}
+	return cfg, fset.Args(), nil
+}
+
+// validate revisa las reglas que dependen de más de un ajuste
+func (c *Config) validate() []string {
+	var problems []string
+	if c.Mail.From == "" {
+		c.Mail.From = c.Mail.User
+	}
+	if c.Mail.Backend == "smtp" {
+		if c.Mail.From == "" {
+			problems = append(problems, "el backend smtp requiere SMTP_FROM o SMTP_USER")
+		}
+		if c.Mail.User != "" && c.Mail.Password == "" {
+			problems = append(problems, "SMTP_USER requiere SMTP_PASSWORD")
+		}
+		// net/smtp se niega a enviar credenciales sin cifrar salvo a localhost
+		if c.Mail.TLS == "none" && c.Mail.User != "" && !isLocalhost(c.Mail.Host) {
+			problems = append(problems, "SMTP_TLS=none solo admite relays sin autenticación; quita SMTP_USER o usa starttls o tls")
+		}
+	}
+	if c.Password.MaxLength != 0 && c.Password.MaxLength < c.Password.MinLength {
+		problems = append(problems, "PASSWORD_MAX_LENGTH debe ser 0 o mayor o igual a PASSWORD_MIN_LENGTH")
+	}
+	return problems
+}
+
+// Usage devuelve la lista de flags con sus variables de entorno y valores por defecto
+func Usage() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  -config\tarchivo .env con la configuración (por defecto %s)\n", DefaultEnvFile)
+	for _, s := range settings {
+		fmt.Fprintf(&b, "  -%s\t%s (%s", s.flag, s.usage, s.env)
+		if s.def != "" {
+			fmt.Fprintf(&b, ", por defecto %s", s.def)
+		}
+		if s.required {
+			b.WriteString(", obligatorio")
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+func isLocalhost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func parsePort(dest *int, v string) error {
+	port, err := strconv.Atoi(v)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("debe ser un puerto entre 1 y 65535")
+	}
+	*dest = port
+	return nil
+}
+
+func parseDuration(dest *time.Duration, v string) error {
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("debe ser una duración positiva, ej. 30s o 2m")
+	}
+	*dest = d
+	return nil
+}
+
+func parseBool(dest *bool, v string) error {
+	b, err := strconv.ParseBool(v)
 	if err != nil {
-		log.Fatalf("Error cargando el archivo de configuración %s: %v", filePath, err)
+		return fmt.Errorf("debe ser true o false")
 	}
+	*dest = b
 	return nil
 }
 
-func GetEnv(key string) string {
-	return os.Getenv(key)
+func oneOf(dest *string, v string, allowed ...string) error {
+	for _, a := range allowed {
+		if v == a {
+			*dest = v
+			return nil
+		}
+	}
+	return fmt.Errorf("debe ser uno de: %s", strings.Join(allowed, ", "))
+}
+
+func absoluteURL(v string) (string, error) {
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return v, fmt.Errorf("debe ser una URL absoluta http(s)")
+	}
+	return v, nil
 }